@@ -1,25 +1,86 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"os"
 
+	_couponRepository "github.com/TewApirat/food-shop/pkg/coupon/repository"
+	_exchangeRateRepository "github.com/TewApirat/food-shop/pkg/exchangeRate/repository"
+	_foodShopController "github.com/TewApirat/food-shop/pkg/foodShop/controller"
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
 	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
 	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
-	_foodShopController "github.com/TewApirat/food-shop/pkg/foodShop/controller"
+	_inventoryRepository "github.com/TewApirat/food-shop/pkg/inventory/repository"
+	_memberRepository "github.com/TewApirat/food-shop/pkg/member/repository"
 	_orderHistoryReppsitory "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
+	_taxInvoiceRepository "github.com/TewApirat/food-shop/pkg/taxInvoice/repository"
 )
 
 func main() {
-	foodShopRepository := _foodShopRepository.NewFoodShopRepositoryDefault()
+	promotionsFile := flag.String("promotions", "", "path to a promotions JSON file (default: built-in promotions)")
+	maxOrderBaht := flag.Int64("max-order-baht", int64(_foodShopService.DefaultMaxOrderValue/100), "largest order subtotal accepted, in baht (0: no limit)")
+	exchangeRatesFile := flag.String("exchange-rates", "", "path to an exchange rates JSON file (default: built-in rates)")
+	taxName := flag.String("tax", "none", "tax policy: none, thai-exclusive (10% service charge + 7% VAT on top) or thai-inclusive (menu prices include VAT)")
+	roundingName := flag.String("rounding", "default", "rounding strategy: default (floor, no cash rounding) or accounting (half-up discounts, half-even tax, 0.25 THB cash)")
+	flag.Parse()
+
+	rounding, err := _foodShopModel.RoundingStrategyByName(*roundingName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	tax, err := _foodShopModel.TaxPolicyByName(*taxName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	promotions := _foodShopRepository.DefaultPromotions()
+	if *promotionsFile != "" {
+		loaded, err := _foodShopRepository.LoadPromotionsFile(*promotionsFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		promotions = loaded
+	}
+
+	exchangeRates := _exchangeRateRepository.DefaultExchangeRates()
+	if *exchangeRatesFile != "" {
+		loaded, err := _exchangeRateRepository.LoadExchangeRatesFile(*exchangeRatesFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		exchangeRates = loaded
+	}
+
+	foodShopRepository := _foodShopRepository.NewFoodShopRepositoryImpl(_foodShopRepository.DefaultMenu(), promotions)
 	orderHistoryRepository := _orderHistoryReppsitory.NewOrderHistoryRepositoryImpl()
+	couponRepository := _couponRepository.NewCouponRepositoryImpl(_couponRepository.DefaultCoupons())
+	memberRepository := _memberRepository.NewMemberRepositoryImpl(_memberRepository.DefaultMembers())
+	exchangeRateRepository := _exchangeRateRepository.NewExchangeRateRepositoryImpl(exchangeRates)
+	taxInvoiceRepository := _taxInvoiceRepository.NewTaxInvoiceRepositoryImpl(_taxInvoiceRepository.DefaultNumberPrefix)
+	inventoryRepository := _inventoryRepository.NewInventoryRepositoryImpl(_inventoryRepository.DefaultStock())
 
 	foodShopService := _foodShopService.NewFoodShopServiceImpl(
 		foodShopRepository,
-		orderHistoryRepository,	
+		orderHistoryRepository,
+		_foodShopService.WithCouponRepository(couponRepository),
+		_foodShopService.WithMemberRepository(memberRepository),
+		_foodShopService.WithExchangeRateRepository(exchangeRateRepository),
+		_foodShopService.WithTaxInvoiceRepository(taxInvoiceRepository),
+		_foodShopService.WithInventoryRepository(inventoryRepository),
+		_foodShopService.WithRoundingStrategy(rounding),
+		_foodShopService.WithTaxPolicy(tax),
+		_foodShopService.WithMaxOrderValue(domain.THB(*maxOrderBaht)),
 	)
 	foodShopController := _foodShopController.NewFoodShopControllerImpl(
-		os.Stdin, 
-		os.Stdout, 
+		os.Stdin,
+		os.Stdout,
 		foodShopService,
 	)
 
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+)
+
+type CouponType string
+
+const (
+	CouponTypeFixed   CouponType = "FIXED"
+	CouponTypePercent CouponType = "PERCENT"
+)
+
+// Coupon is a voucher redeemed by code. Zero values mean "no limit":
+// no expiry, no minimum spend, any item and unlimited redemptions.
+type Coupon struct {
+	Code                      string
+	Type                      CouponType
+	Amount                    domain.Money
+	Rate                      domain.Rate
+	MinSpend                  domain.Money
+	EligibleCodes             []_foodShopModel.MenuItemCode
+	ExpiresAt                 time.Time
+	MaxRedemptions            int
+	MaxRedemptionsPerCustomer int
+}
+
+type Redemption struct {
+	Code       string
+	CustomerID string
+	OrderNo    int
+	RedeemedAt time.Time
+}
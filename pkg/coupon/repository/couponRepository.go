@@ -0,0 +1,9 @@
+package repository
+
+import "github.com/TewApirat/food-shop/pkg/coupon/model"
+
+type CouponRepository interface {
+	FindByCode(code string) (model.Coupon, error)
+	CountRedemptions(code string, customerID string) (total int, byCustomer int, err error)
+	Redeem(redemptions []model.Redemption) error
+}
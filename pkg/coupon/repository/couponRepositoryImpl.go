@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"sync"
+	"time"
+
+	"github.com/TewApirat/food-shop/pkg/coupon/model"
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	"github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+)
+
+type couponRepositoryImpl struct {
+	mu          sync.Mutex
+	coupons     map[string]model.Coupon
+	redemptions []model.Redemption
+}
+
+func NewCouponRepositoryImpl(coupons []model.Coupon) CouponRepository {
+	ownedCoupons := make(map[string]model.Coupon, len(coupons))
+	for _, coupon := range coupons {
+		coupon.EligibleCodes = append([]_foodShopModel.MenuItemCode(nil), coupon.EligibleCodes...)
+		ownedCoupons[coupon.Code] = coupon
+	}
+
+	return &couponRepositoryImpl{
+		coupons:     ownedCoupons,
+		redemptions: make([]model.Redemption, 0),
+	}
+}
+
+func DefaultCoupons() []model.Coupon {
+	return []model.Coupon{
+		{
+			Code:                      "WELCOME50",
+			Type:                      model.CouponTypeFixed,
+			Amount:                    domain.THB(50),
+			MinSpend:                  domain.THB(300),
+			MaxRedemptionsPerCustomer: 1,
+		},
+		{
+			Code:          "ORANGE10",
+			Type:          model.CouponTypePercent,
+			Rate:          domain.PercentRate(10),
+			EligibleCodes: []_foodShopModel.MenuItemCode{"ORANGE"},
+			ExpiresAt:     time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func (r *couponRepositoryImpl) FindByCode(code string) (model.Coupon, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	coupon, ok := r.coupons[code]
+	if !ok {
+		return model.Coupon{}, &exception.InvalidCouponError{Code: code}
+	}
+	return coupon, nil
+}
+
+func (r *couponRepositoryImpl) CountRedemptions(code string, customerID string) (int, int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	total, byCustomer := r.countLocked(code, customerID)
+	return total, byCustomer, nil
+}
+
+// Redeem records every redemption or none of them, re-checking the limits
+// under the lock so two concurrent orders cannot both take the last use.
+func (r *couponRepositoryImpl) Redeem(redemptions []model.Redemption) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, redemption := range redemptions {
+		coupon, ok := r.coupons[redemption.Code]
+		if !ok {
+			return &exception.InvalidCouponError{Code: redemption.Code}
+		}
+
+		total, byCustomer := r.countLocked(redemption.Code, redemption.CustomerID)
+		if coupon.MaxRedemptions > 0 && total >= coupon.MaxRedemptions {
+			return &exception.CouponExhaustedError{Code: coupon.Code, Limit: coupon.MaxRedemptions}
+		}
+		if coupon.MaxRedemptionsPerCustomer > 0 && byCustomer >= coupon.MaxRedemptionsPerCustomer {
+			return &exception.CouponExhaustedError{Code: coupon.Code, Limit: coupon.MaxRedemptionsPerCustomer, PerCustomer: true}
+		}
+	}
+
+	r.redemptions = append(r.redemptions, redemptions...)
+	return nil
+}
+
+func (r *couponRepositoryImpl) countLocked(code string, customerID string) (int, int) {
+	total, byCustomer := 0, 0
+	for _, redemption := range r.redemptions {
+		if redemption.Code != code {
+			continue
+		}
+		total++
+		if customerID != "" && redemption.CustomerID == customerID {
+			byCustomer++
+		}
+	}
+	return total, byCustomer
+}
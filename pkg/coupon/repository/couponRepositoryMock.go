@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TewApirat/food-shop/pkg/coupon/model"
+)
+
+type CouponRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *CouponRepositoryMock) FindByCode(code string) (model.Coupon, error) {
+	args := m.Called(code)
+	return args.Get(0).(model.Coupon), args.Error(1)
+}
+
+func (m *CouponRepositoryMock) CountRedemptions(code string, customerID string) (int, int, error) {
+	args := m.Called(code, customerID)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *CouponRepositoryMock) Redeem(redemptions []model.Redemption) error {
+	args := m.Called(redemptions)
+	return args.Error(0)
+}
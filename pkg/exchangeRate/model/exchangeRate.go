@@ -0,0 +1,15 @@
+package model
+
+import (
+	"time"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+)
+
+// ExchangeRate is the THB price of one unit of Currency as published at
+// UpdatedAt.
+type ExchangeRate struct {
+	Currency   domain.Currency     `json:"currency"`
+	THBPerUnit domain.ExchangeRate `json:"thbPerUnit"`
+	UpdatedAt  time.Time           `json:"updatedAt"`
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/TewApirat/food-shop/pkg/exchangeRate/model"
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	"github.com/TewApirat/food-shop/pkg/foodShop/exception"
+)
+
+// LoadExchangeRatesFile reads a JSON array of exchange rates, e.g.
+// config/exchangeRates.json.
+func LoadExchangeRatesFile(path string) ([]model.ExchangeRate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read exchange rates file %s: %w", path, err)
+	}
+	return ParseExchangeRates(data)
+}
+
+func ParseExchangeRates(data []byte) ([]model.ExchangeRate, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var raw []struct {
+		Currency   string              `json:"currency"`
+		THBPerUnit domain.ExchangeRate `json:"thbPerUnit"`
+		UpdatedAt  time.Time           `json:"updatedAt"`
+	}
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, &exception.InvalidExchangeRateConfigError{Reason: err.Error()}
+	}
+
+	rates := make([]model.ExchangeRate, 0, len(raw))
+	seen := make(map[domain.Currency]bool, len(raw))
+	for _, entry := range raw {
+		currency, err := domain.ParseCurrency(entry.Currency)
+		if err != nil {
+			return nil, &exception.InvalidExchangeRateConfigError{Currency: entry.Currency, Reason: err.Error()}
+		}
+		if currency == domain.CurrencyTHB {
+			return nil, &exception.InvalidExchangeRateConfigError{Currency: entry.Currency, Reason: "THB is the settlement currency and has no rate"}
+		}
+		if seen[currency] {
+			return nil, &exception.InvalidExchangeRateConfigError{Currency: entry.Currency, Reason: "duplicate currency"}
+		}
+		seen[currency] = true
+
+		if entry.THBPerUnit <= 0 {
+			return nil, &exception.InvalidExchangeRateConfigError{Currency: entry.Currency, Reason: "thbPerUnit is required"}
+		}
+		if entry.UpdatedAt.IsZero() {
+			return nil, &exception.InvalidExchangeRateConfigError{Currency: entry.Currency, Reason: "updatedAt is required"}
+		}
+
+		rates = append(rates, model.ExchangeRate{
+			Currency:   currency,
+			THBPerUnit: entry.THBPerUnit,
+			UpdatedAt:  entry.UpdatedAt,
+		})
+	}
+	return rates, nil
+}
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"github.com/TewApirat/food-shop/pkg/exchangeRate/model"
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+)
+
+type ExchangeRateRepository interface {
+	FindRate(currency domain.Currency) (model.ExchangeRate, error)
+	ListRates() ([]model.ExchangeRate, error)
+}
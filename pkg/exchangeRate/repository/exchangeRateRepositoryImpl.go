@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/TewApirat/food-shop/pkg/exchangeRate/model"
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	"github.com/TewApirat/food-shop/pkg/foodShop/exception"
+)
+
+type exchangeRateRepositoryImpl struct {
+	mu         sync.Mutex
+	byCurrency map[domain.Currency]model.ExchangeRate
+}
+
+func NewExchangeRateRepositoryImpl(rates []model.ExchangeRate) ExchangeRateRepository {
+	byCurrency := make(map[domain.Currency]model.ExchangeRate, len(rates))
+	for _, rate := range rates {
+		byCurrency[rate.Currency] = rate
+	}
+
+	return &exchangeRateRepositoryImpl{
+		byCurrency: byCurrency,
+	}
+}
+
+// DefaultExchangeRates mirrors config/exchangeRates.json.
+func DefaultExchangeRates() []model.ExchangeRate {
+	updatedAt := time.Date(2026, time.October, 1, 9, 0, 0, 0, time.FixedZone("ICT", 7*60*60))
+	return []model.ExchangeRate{
+		{Currency: domain.CurrencyUSD, THBPerUnit: 36_500_000, UpdatedAt: updatedAt},
+		{Currency: domain.CurrencyEUR, THBPerUnit: 39_250_000, UpdatedAt: updatedAt},
+		{Currency: domain.CurrencyCNY, THBPerUnit: 5_050_000, UpdatedAt: updatedAt},
+		{Currency: domain.CurrencyJPY, THBPerUnit: 241_500, UpdatedAt: updatedAt},
+	}
+}
+
+func (r *exchangeRateRepositoryImpl) FindRate(currency domain.Currency) (model.ExchangeRate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rate, ok := r.byCurrency[currency]
+	if !ok {
+		return model.ExchangeRate{}, &exception.ExchangeRateNotFoundError{Currency: currency}
+	}
+	return rate, nil
+}
+
+// ListRates returns every rate ordered by currency code.
+func (r *exchangeRateRepositoryImpl) ListRates() ([]model.ExchangeRate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rates := make([]model.ExchangeRate, 0, len(r.byCurrency))
+	for _, rate := range r.byCurrency {
+		rates = append(rates, rate)
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].Currency < rates[j].Currency
+	})
+	return rates, nil
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TewApirat/food-shop/pkg/exchangeRate/model"
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+)
+
+type ExchangeRateRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *ExchangeRateRepositoryMock) FindRate(currency domain.Currency) (model.ExchangeRate, error) {
+	args := m.Called(currency)
+	return args.Get(0).(model.ExchangeRate), args.Error(1)
+}
+
+func (m *ExchangeRateRepositoryMock) ListRates() ([]model.ExchangeRate, error) {
+	args := m.Called()
+	return args.Get(0).([]model.ExchangeRate), args.Error(1)
+}
@@ -5,12 +5,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/chzyer/readline"
 
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
 	"github.com/TewApirat/food-shop/pkg/foodShop/model"
 	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
+	_memberModel "github.com/TewApirat/food-shop/pkg/member/model"
+	_taxInvoiceModel "github.com/TewApirat/food-shop/pkg/taxInvoice/model"
 )
 
 type FoodShopControllerImpl struct {
@@ -47,6 +52,10 @@ func (c *FoodShopControllerImpl) ServeCLI() {
 		fmt.Fprintln(c.out, "2) View all promotions")
 		fmt.Fprintln(c.out, "3) Quote order (JSON input)")
 		fmt.Fprintln(c.out, "4) View order history")
+		fmt.Fprintln(c.out, "5) Issue tax invoice (JSON input)")
+		fmt.Fprintln(c.out, "6) Reprint tax invoice")
+		fmt.Fprintln(c.out, "7) Cancel order")
+		fmt.Fprintln(c.out, "8) Admin: manage menu")
 		fmt.Fprintln(c.out, "0) Exit")
 
 		rl.SetPrompt("Select: ")
@@ -65,7 +74,9 @@ func (c *FoodShopControllerImpl) ServeCLI() {
 
 		switch choice {
 		case "1":
-			c.handleViewMenu()
+			if ok := c.handleViewMenu(rl); !ok {
+				return
+			}
 		case "2":
 			c.handleViewPromotions()
 		case "3":
@@ -74,32 +85,108 @@ func (c *FoodShopControllerImpl) ServeCLI() {
 			}
 		case "4":
 			c.handleViewOrderHistory()
+		case "5":
+			if ok := c.handleIssueTaxInvoice(rl); !ok {
+				return
+			}
+		case "6":
+			if ok := c.handleReprintTaxInvoice(rl); !ok {
+				return
+			}
+		case "7":
+			if ok := c.handleCancelOrder(rl); !ok {
+				return
+			}
+		case "8":
+			if ok := c.handleAdmin(rl); !ok {
+				return
+			}
 		case "0":
 			fmt.Fprintln(c.out, "Thankyou.")
 			return
 		default:
-			fmt.Fprintln(c.out, "Invalid choice. Please select 0-3.")
+			fmt.Fprintln(c.out, "Invalid choice. Please select 0-8.")
 		}
 	}
 }
 
-func (c *FoodShopControllerImpl) handleViewMenu() {
-	items, err := c.foodShopService.GetMenuCatalog()
+func (c *FoodShopControllerImpl) handleViewMenu(rl *readline.Instance) bool {
+	fmt.Fprintln(c.out, "\nFilter by category (SET, DRINK, DESSERT) and/or tag (SPICY, NEW, BESTSELLER), or press Enter for all.")
+
+	rl.SetPrompt("Filter: ")
+	line, err := readLine(rl)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			fmt.Fprintln(c.out, "\nEOF received. Bye.")
+			return false
+		}
+		fmt.Fprintln(c.out, "Read error:", err)
+		return false
+	}
+
+	filter, err := model.ParseMenuFilter(line)
+	if err != nil {
+		fmt.Fprintln(c.out, "Error:", err)
+		return true
+	}
+
+	items, err := c.foodShopService.FilterMenuAvailability(filter)
 	if err != nil {
 		fmt.Fprintln(c.out, err)
-		return
+		return true
+	}
+
+	stock, err := c.foodShopService.ListStock()
+	if err != nil {
+		fmt.Fprintln(c.out, err)
+		return true
+	}
+	remaining := make(map[model.MenuItemCode]int, len(stock))
+	for _, level := range stock {
+		remaining[level.Code] = level.Remaining
 	}
 
 	fmt.Fprintln(c.out)
 	fmt.Fprintln(c.out, "--- Menu Catalog ---")
-	fmt.Fprintln(c.out)
-	fmt.Fprintln(c.out, "--------+--------------+-----------")
-	fmt.Fprintln(c.out, "CODE    | NAME         | PRICE		")
-	fmt.Fprintln(c.out, "--------+--------------+-----------")
+	fmt.Fprintln(c.out, "Items marked NOT NOW cannot be ordered right now.")
 
-	for _, it := range items {
-		fmt.Fprintf(c.out, "%-7s | %-12s | %s\n", it.Code, it.Name, it.Price.String())
+	if len(items) == 0 {
+		fmt.Fprintln(c.out)
+		fmt.Fprintln(c.out, "No menu items match.")
+		return true
 	}
+
+	// Items arrive sorted by category, so a new heading starts each group.
+	for i, it := range items {
+		if i == 0 || it.Category != items[i-1].Category {
+			fmt.Fprintln(c.out)
+			fmt.Fprintf(c.out, "== %s ==\n", it.Category.Title())
+			fmt.Fprintln(c.out, "---------+--------------+------------+-----------")
+			fmt.Fprintln(c.out, "CODE     | NAME         | PRICE      | NOTES")
+			fmt.Fprintln(c.out, "---------+--------------+------------+-----------")
+		}
+		notes := menuNotes(it.MenuItem)
+		if left, ok := remaining[it.Code]; ok {
+			notes = prependNote(stockLabel(model.StockLevel{Code: it.Code, Remaining: left}), notes)
+		}
+		if !it.Available {
+			notes = prependNote(availabilityLabel(it), notes)
+		}
+		fmt.Fprintf(c.out, "%-8s | %-12s | %-10s | %s\n", it.Code, it.Name, it.CurrentPrice.Price.String(), notes)
+		if it.Description != "" {
+			fmt.Fprintf(c.out, "%-8s   %s\n", "", it.Description)
+		}
+		if it.NextPrice != nil {
+			fmt.Fprintf(c.out, "%-8s   Price changes to %s on %s\n", "", it.NextPrice.Price.String(), it.NextPrice.EffectiveFrom.Format("Mon 2006-01-02 15:04"))
+		}
+		if it.Availability != nil {
+			fmt.Fprintf(c.out, "%-8s   Served: %s\n", "", it.Availability.String())
+		}
+		for _, group := range it.Modifiers {
+			fmt.Fprintf(c.out, "%-8s   %s (%s): %s\n", "", group.Name, group.Rule(), optionsLabel(group.Options))
+		}
+	}
+	return true
 }
 
 func (c *FoodShopControllerImpl) handleViewPromotions() {
@@ -114,15 +201,26 @@ func (c *FoodShopControllerImpl) handleViewPromotions() {
 	fmt.Fprintln(c.out)
 
 	for _, p := range promos {
-		fmt.Fprintf(c.out, "[%s] %s\n", p.Code, p.Title)
+		if p.Active {
+			fmt.Fprintf(c.out, "[%s] %s\n", p.Code, p.Title)
+		} else {
+			fmt.Fprintf(c.out, "[%s] %s (inactive)\n", p.Code, p.Title)
+		}
 		fmt.Fprintf(c.out, " - %s\n", p.Description)
+		if p.Schedule != nil {
+			fmt.Fprintf(c.out, " - Valid: %s\n", p.Schedule.String())
+		}
 		fmt.Fprintln(c.out)
 	}
 }
 
 func (c *FoodShopControllerImpl) handleQuoteOrderJSON(rl *readline.Instance) bool {
 	fmt.Fprintln(c.out, "\nPaste order JSON in one line, then press Enter.")
-	fmt.Fprintln(c.out, `Example: {"items":{"RED":1,"GREEN":2},"member":false}`)
+	fmt.Fprintln(c.out, `Example: {"items":{"RED":1,"GREEN":2}}`)
+	fmt.Fprintln(c.out, `Member:  {"items":{"GREEN":2},"memberId":"M0002"}`)
+	fmt.Fprintln(c.out, `Options: {"lines":[{"code":"RED","qty":1,"options":["LARGE","EXTRA_EGG","NO_CHILI"]}]}`)
+	fmt.Fprintln(c.out, `Coupons: {"items":{"ORANGE":3},"customerId":"C001","coupons":["WELCOME50"]}`)
+	fmt.Fprintln(c.out, `Display: {"items":{"RED":1},"displayCurrency":"USD"}`)
 
 	rl.SetPrompt("Order JSON: ")
 	line, err := readLine(rl)
@@ -143,7 +241,7 @@ func (c *FoodShopControllerImpl) handleQuoteOrderJSON(rl *readline.Instance) boo
 	var req model.PurchasingRequest
 	if err := json.Unmarshal([]byte(line), &req); err != nil {
 		fmt.Fprintln(c.out, "Error: invalid JSON:", err)
-		fmt.Fprintln(c.out, `Hint: {"items":{"RED":1,"GREEN":2},"member":false}`)
+		fmt.Fprintln(c.out, `Hint: {"items":{"RED":1,"GREEN":2},"memberId":"M0002"}`)
 		return true
 	}
 
@@ -156,24 +254,62 @@ func (c *FoodShopControllerImpl) handleQuoteOrderJSON(rl *readline.Instance) boo
 
 	fmt.Fprintln(c.out, "\n--- Order Items ---")
 	fmt.Fprintln(c.out)
-	fmt.Fprintln(c.out, "--------+--------------+-----+------------+-----------")
-	fmt.Fprintln(c.out, "CODE    | NAME         | QTY | UNIT PRICE |   TOTAL.  ")
-	fmt.Fprintln(c.out, "--------+--------------+-----+------------+-----------")
+	fmt.Fprintln(c.out, "--------+--------------+-----+------+------------+------------+-----------")
+	fmt.Fprintln(c.out, "CODE    | NAME         | QTY | FREE | UNIT PRICE |   TOTAL.   |    NET    ")
+	fmt.Fprintln(c.out, "--------+--------------+-----+------+------------+------------+-----------")
 
 	for _, ln := range quote.Lines {
-    	fmt.Fprintf(
-        c.out,
-        "%-7s | %-12s | %3d | %-10s | %s\n",
-        ln.Code, ln.Name, ln.Qty, ln.UnitPrice.String(), ln.LineTotal.String(),
+		fmt.Fprintf(
+			c.out,
+			"%-7s | %-12s | %3d | %4s | %-10s | %-10s | %s\n",
+			ln.Code, ln.Name, ln.Qty, freeQtyLabel(ln.FreeQty), ln.UnitPrice.String(), ln.LineTotal.String(), ln.NetTotal.String(),
 		)
+		c.printMenuPrice(ln.MenuPrice)
+		c.printLineOptions(ln.Options)
 	}
 
 	fmt.Fprintln(c.out, "\n--- Order Quote ---")
 	fmt.Fprintln(c.out)
+	if quote.MemberID != "" {
+		fmt.Fprintf(c.out, "%-16s : %s (%s)\n", "Member", quote.MemberID, quote.MemberTier)
+	}
 	fmt.Fprintf(c.out, "%-16s : %s\n", "Subtotal",        quote.Subtotal.String())
 	fmt.Fprintf(c.out, "%-16s : %s\n", "Pair Discount",   quote.PairDiscount.String())
 	fmt.Fprintf(c.out, "%-16s : %s\n", "Member Discount", quote.MemberDiscount.String())
+	fmt.Fprintf(c.out, "%-16s : %s\n", "Promo Discount", quote.PromotionDiscount.String())
+	fmt.Fprintf(c.out, "%-16s : %s\n", "Coupon Discount", quote.CouponDiscount.String())
+	for _, coupon := range quote.Coupons {
+		fmt.Fprintf(c.out, "  - %-12s : %s\n", coupon.Code, coupon.Discount.String())
+	}
 	fmt.Fprintf(c.out, "%-16s : %s\n", "Total",           quote.Total.String())	
+	c.printTaxes(quote.Tax, quote.PreTax, quote.ServiceCharge, quote.VAT, quote.GrandTotal)
+	if quote.Rounding.CashUnit > 0 {
+		fmt.Fprintf(c.out, "%-16s : %s\n", "Cash Rounding", quote.CashRounding.String())
+		fmt.Fprintf(c.out, "%-16s : %s\n", "Cash Total", quote.CashTotal.String())
+	}
+	c.printAmountInWords(quote.CashTotal)
+	c.printDisplayTotal(quote.Display)
+
+	if len(quote.Stock) > 0 {
+		left := make([]string, 0, len(quote.Stock))
+		for _, level := range quote.Stock {
+			left = append(left, fmt.Sprintf("%s %s", level.Code, stockLabel(level)))
+		}
+		fmt.Fprintf(c.out, "\n%-16s : %s\n", "Stock", strings.Join(left, ", "))
+	}
+
+	c.printAppliedPromotions(quote.AppliedPromotions)
+
+	if len(quote.RejectedCombinations) > 0 {
+		fmt.Fprintf(c.out, "\n%-16s : %s\n", "Best deal", combinationLabel(quote.ChosenCombination))
+		for _, alt := range quote.RejectedCombinations {
+			fmt.Fprintf(c.out, "  not chosen     : %s => %s\n", combinationLabel(alt.Codes), alt.Total.String())
+		}
+	}
+
+	for _, hint := range quote.NextTiers {
+		fmt.Fprintf(c.out, "\nTip: %s (%s)\n", hint.Message(), hint.PromotionCode)
+	}
 
 	return true
 }
@@ -202,27 +338,401 @@ func (c *FoodShopControllerImpl) handleViewOrderHistory() {
 	}
 
 	for _, e := range entries {
-		fmt.Fprintf(c.out, "Order #%d | %s | member=%v\n",
-			e.OrderNo, e.CreatedAt.Format("2006-01-02 15:04:05"), e.Member)
+		fmt.Fprintf(c.out, "Order #%d | %s | member=%s\n",
+			e.OrderNo, e.CreatedAt.Format("2006-01-02 15:04:05"), memberLabel(e.MemberID, e.MemberTier))
+		if e.Cancelled() {
+			fmt.Fprintf(c.out, "CANCELLED at %s\n", e.CancelledAt.Format("2006-01-02 15:04:05"))
+		}
 		fmt.Fprintln(c.out)
 
-		fmt.Fprintln(c.out, "CODE    | NAME         | QTY | UNIT PRICE | LINE TOTAL")
-		fmt.Fprintln(c.out, "--------+--------------+-----+------------+-----------")
+		fmt.Fprintln(c.out, "CODE    | NAME         | QTY | FREE | UNIT PRICE | LINE TOTAL | NET")
+		fmt.Fprintln(c.out, "--------+--------------+-----+------+------------+------------+-----------")
 		for _, ln := range e.Line {
-			fmt.Fprintf(c.out, "%-7s | %-12s | %3d | %-10s | %s\n",
-				ln.Code, ln.Name, ln.Qty, ln.UnitPrice.String(), ln.LineTotal.String())
+			fmt.Fprintf(c.out, "%-7s | %-12s | %3d | %4s | %-10s | %-10s | %s\n",
+				ln.Code, ln.Name, ln.Qty, freeQtyLabel(ln.FreeQty), ln.UnitPrice.String(), ln.LineTotal.String(), ln.NetTotal.String())
+			c.printMenuPrice(ln.MenuPrice)
+			c.printLineOptions(ln.Options)
 		}
 
 		fmt.Fprintln(c.out)
 		fmt.Fprintf(c.out, "%-16s : %s\n", "Subtotal",        e.Subtotal.String())
 		fmt.Fprintf(c.out, "%-16s : %s\n", "Pair Discount",   e.PairDiscount.String())
 		fmt.Fprintf(c.out, "%-16s : %s\n", "Member Discount", e.MemberDiscount.String())
+		fmt.Fprintf(c.out, "%-16s : %s\n", "Promo Discount", e.PromotionDiscount.String())
+		fmt.Fprintf(c.out, "%-16s : %s\n", "Coupon Discount", e.CouponDiscount.String())
+		for _, coupon := range e.Coupons {
+			fmt.Fprintf(c.out, "  - %-12s : %s\n", coupon.Code, coupon.Discount.String())
+		}
 		fmt.Fprintf(c.out, "%-16s : %s\n", "Total",           e.Total.String())
+		c.printTaxes(e.Tax, e.PreTax, e.ServiceCharge, e.VAT, e.GrandTotal)
+		if e.Rounding.CashUnit > 0 {
+			fmt.Fprintf(c.out, "%-16s : %s\n", "Cash Rounding", e.CashRounding.String())
+			fmt.Fprintf(c.out, "%-16s : %s\n", "Cash Total", e.CashTotal.String())
+		}
+		c.printAmountInWords(e.CashTotal)
+		c.printDisplayTotal(e.Display)
+		c.printAppliedPromotions(e.AppliedPromotions)
 		fmt.Fprintln(c.out, "\n------------------------------")
 		fmt.Fprintln(c.out)
 	}
 }
 
+func (c *FoodShopControllerImpl) handleIssueTaxInvoice(rl *readline.Instance) bool {
+	fmt.Fprintln(c.out, "\nPaste the order number and buyer JSON in one line, then press Enter.")
+	fmt.Fprintln(c.out, `Example: {"orderNo":1,"buyer":{"name":"Siam Trading Co., Ltd.","taxId":"3105500123452","branch":"00000","address":"1 Rama IV Road, Bangkok 10500"}}`)
+
+	rl.SetPrompt("Invoice JSON: ")
+	line, err := readLine(rl)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			fmt.Fprintln(c.out, "\nEOF received. Bye.")
+			return false
+		}
+		fmt.Fprintln(c.out, "Read error:", err)
+		return false
+	}
+
+	if strings.TrimSpace(line) == "" {
+		fmt.Fprintln(c.out, "Error: empty input")
+		return true
+	}
+
+	var req _taxInvoiceModel.IssueRequest
+	if err := json.Unmarshal([]byte(line), &req); err != nil {
+		fmt.Fprintln(c.out, "Error: invalid JSON:", err)
+		return true
+	}
+
+	invoice, err := c.foodShopService.IssueTaxInvoice(req)
+	if err != nil {
+		fmt.Fprintln(c.out, err)
+		return true
+	}
+
+	fmt.Fprintln(c.out)
+	fmt.Fprint(c.out, invoice.Text())
+	return true
+}
+
+func (c *FoodShopControllerImpl) handleReprintTaxInvoice(rl *readline.Instance) bool {
+	fmt.Fprintln(c.out, "\nEnter the invoice number, add \"json\" for JSON (e.g. TI000001 json).")
+
+	rl.SetPrompt("Invoice No.: ")
+	line, err := readLine(rl)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			fmt.Fprintln(c.out, "\nEOF received. Bye.")
+			return false
+		}
+		fmt.Fprintln(c.out, "Read error:", err)
+		return false
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		fmt.Fprintln(c.out, "Error: empty input")
+		return true
+	}
+
+	invoice, err := c.foodShopService.GetTaxInvoice(fields[0])
+	if err != nil {
+		fmt.Fprintln(c.out, err)
+		return true
+	}
+
+	fmt.Fprintln(c.out)
+	if len(fields) > 1 && strings.EqualFold(fields[1], "json") {
+		data, err := invoice.JSON()
+		if err != nil {
+			fmt.Fprintln(c.out, "Error:", err)
+			return true
+		}
+		fmt.Fprintln(c.out, string(data))
+		return true
+	}
+	fmt.Fprint(c.out, invoice.Text())
+	return true
+}
+
+func (c *FoodShopControllerImpl) handleCancelOrder(rl *readline.Instance) bool {
+	fmt.Fprintln(c.out, "\nEnter the number of the order to cancel; its stock is put back.")
+
+	rl.SetPrompt("Order No.: ")
+	line, err := readLine(rl)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			fmt.Fprintln(c.out, "\nEOF received. Bye.")
+			return false
+		}
+		fmt.Fprintln(c.out, "Read error:", err)
+		return false
+	}
+
+	orderNo, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(line), "#"))
+	if err != nil {
+		fmt.Fprintln(c.out, "Error: invalid order number:", strings.TrimSpace(line))
+		return true
+	}
+
+	entry, err := c.foodShopService.CancelOrder(orderNo)
+	if err != nil {
+		fmt.Fprintln(c.out, err)
+		return true
+	}
+
+	fmt.Fprintf(c.out, "\nOrder #%d cancelled at %s.\n", entry.OrderNo, entry.CancelledAt.Format("2006-01-02 15:04:05"))
+	return true
+}
+
+// handleAdmin runs the admin section until the user goes back to the
+// main menu. It returns false when input has ended.
+func (c *FoodShopControllerImpl) handleAdmin(rl *readline.Instance) bool {
+	for {
+		fmt.Fprintln(c.out, "\n==== Admin: Menu ====")
+		fmt.Fprintln(c.out, "1) Add item (JSON input)")
+		fmt.Fprintln(c.out, "2) Update name/price (JSON input)")
+		fmt.Fprintln(c.out, "3) Retire item")
+		fmt.Fprintln(c.out, "4) List all items, including retired")
+		fmt.Fprintln(c.out, "5) Price history")
+		fmt.Fprintln(c.out, "0) Back")
+
+		choice, ok := c.readPrompt(rl, "Admin: ")
+		if !ok {
+			return false
+		}
+
+		switch strings.TrimSpace(choice) {
+		case "":
+			continue
+		case "1":
+			fmt.Fprintln(c.out, "\nPaste the new item JSON in one line, then press Enter.")
+			fmt.Fprintln(c.out, `Example: {"code":"BROWN","name":"Brown set","price":"65","category":"SET","description":"Stir-fried basil pork with rice"}`)
+			line, ok := c.readPrompt(rl, "Item JSON: ")
+			if !ok {
+				return false
+			}
+			var item model.MenuItem
+			if err := json.Unmarshal([]byte(line), &item); err != nil {
+				fmt.Fprintln(c.out, "Error: invalid JSON:", err)
+				continue
+			}
+			added, err := c.foodShopService.AddMenuItem(item)
+			if err != nil {
+				fmt.Fprintln(c.out, err)
+				continue
+			}
+			fmt.Fprintf(c.out, "Added %s %s at %s.\n", added.Code, added.Name, added.Price.String())
+		case "2":
+			fmt.Fprintln(c.out, "\nPaste the code with the new name and/or price, then press Enter.")
+			fmt.Fprintln(c.out, "A new price takes effect now, or at effectiveFrom when given.")
+			fmt.Fprintln(c.out, `Example: {"code":"ORANGE","price":"130","effectiveFrom":"2026-11-01T00:00:00+07:00"}`)
+			line, ok := c.readPrompt(rl, "Update JSON: ")
+			if !ok {
+				return false
+			}
+			var update model.MenuItemUpdate
+			if err := json.Unmarshal([]byte(line), &update); err != nil {
+				fmt.Fprintln(c.out, "Error: invalid JSON:", err)
+				continue
+			}
+			updated, err := c.foodShopService.UpdateMenuItem(update)
+			if err != nil {
+				fmt.Fprintln(c.out, err)
+				continue
+			}
+			prices := updated.Prices()
+			latest := prices[len(prices)-1]
+			fmt.Fprintf(c.out, "Updated %s: %s, price v%d %s", updated.Code, updated.Name, latest.Version, latest.Price.String())
+			if latest.Version > 1 {
+				fmt.Fprintf(c.out, " from %s", latest.EffectiveFrom.Format("2006-01-02 15:04"))
+			}
+			fmt.Fprintln(c.out, ".")
+		case "3":
+			line, ok := c.readPrompt(rl, "Code to retire: ")
+			if !ok {
+				return false
+			}
+			retired, err := c.foodShopService.RetireMenuItem(line)
+			if err != nil {
+				fmt.Fprintln(c.out, err)
+				continue
+			}
+			fmt.Fprintf(c.out, "Retired %s %s; past orders keep it.\n", retired.Code, retired.Name)
+		case "4":
+			items, err := c.foodShopService.FilterMenuAvailability(model.MenuFilter{IncludeRetired: true})
+			if err != nil {
+				fmt.Fprintln(c.out, err)
+				continue
+			}
+			fmt.Fprintln(c.out)
+			for _, it := range items {
+				status := string(it.Category)
+				if it.Retired() {
+					status = "RETIRED " + it.RetiredAt.Format("2006-01-02 15:04")
+				}
+				fmt.Fprintf(c.out, "%-8s | %-12s | %-10s | %s\n", it.Code, it.Name, it.CurrentPrice.Price.String(), status)
+			}
+		case "5":
+			fmt.Fprintln(c.out, "\nEnter a code, optionally with a date or date and time (Asia/Bangkok), e.g. ORANGE 2026-03-03.")
+			line, ok := c.readPrompt(rl, "Code [date]: ")
+			if !ok {
+				return false
+			}
+			c.printPriceHistory(line)
+		case "0":
+			return true
+		default:
+			fmt.Fprintln(c.out, "Invalid choice. Please select 0-5.")
+		}
+	}
+}
+
+// printPriceHistory lists every price version of the code on the line
+// and, when a date follows it, the price in effect at that moment.
+func (c *FoodShopControllerImpl) printPriceHistory(line string) {
+	code, when, _ := strings.Cut(strings.TrimSpace(line), " ")
+	history, err := c.foodShopService.GetPriceHistory(code)
+	if err != nil {
+		fmt.Fprintln(c.out, err)
+		return
+	}
+
+	fmt.Fprintln(c.out)
+	for _, version := range history {
+		from := "opening price"
+		if version.Version > 1 {
+			from = "from " + version.EffectiveFrom.Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(c.out, "v%-3d | %-10s | %s\n", version.Version, version.Price.String(), from)
+	}
+
+	when = strings.TrimSpace(when)
+	if when == "" {
+		return
+	}
+	at, err := parseLocalTime(when)
+	if err != nil {
+		fmt.Fprintln(c.out, "Error:", err)
+		return
+	}
+	price, err := c.foodShopService.GetPriceAt(code, at)
+	if err != nil {
+		fmt.Fprintln(c.out, err)
+		return
+	}
+	fmt.Fprintf(c.out, "\nPrice at %s: %s (v%d)\n", at.Format("2006-01-02 15:04"), price.Price.String(), price.Version)
+}
+
+// parseLocalTime reads "2006-01-02" (start of the day) or
+// "2006-01-02 15:04" in the shop's timezone.
+func parseLocalTime(s string) (time.Time, error) {
+	loc, err := time.LoadLocation(model.DefaultTimezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q (want YYYY-MM-DD or YYYY-MM-DD HH:MM)", s)
+}
+
+// readPrompt reads one line after prompt. It returns false when input has
+// ended or cannot be read.
+func (c *FoodShopControllerImpl) readPrompt(rl *readline.Instance, prompt string) (string, bool) {
+	rl.SetPrompt(prompt)
+	line, err := readLine(rl)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			fmt.Fprintln(c.out, "\nEOF received. Bye.")
+			return "", false
+		}
+		fmt.Fprintln(c.out, "Read error:", err)
+		return "", false
+	}
+	return line, true
+}
+
+// optionsLabel lists a group's options with their codes and any price
+// delta, e.g. "LARGE Large +15.00".
+func optionsLabel(options []model.ModifierOption) string {
+	labels := make([]string, 0, len(options))
+	for _, option := range options {
+		label := option.Code + " " + option.Name
+		if option.PriceDelta != 0 {
+			label += " " + signedAmount(option.PriceDelta)
+		}
+		labels = append(labels, label)
+	}
+	return strings.Join(labels, ", ")
+}
+
+// signedAmount writes a price delta with its sign, e.g. "+15.00".
+func signedAmount(m domain.Money) string {
+	if m < 0 {
+		return m.Decimal()
+	}
+	return "+" + m.Decimal()
+}
+
+// menuNotes lists an item's tags and dietary flags, e.g.
+// "SPICY, BESTSELLER; halal".
+func menuNotes(item model.MenuItem) string {
+	tags := make([]string, 0, len(item.Tags))
+	for _, tag := range item.Tags {
+		tags = append(tags, string(tag))
+	}
+	dietary := make([]string, 0, len(item.Dietary))
+	for _, flag := range item.Dietary {
+		dietary = append(dietary, flag.Label())
+	}
+
+	notes := strings.Join(tags, ", ")
+	if len(dietary) > 0 {
+		if notes != "" {
+			notes += "; "
+		}
+		notes += strings.Join(dietary, ", ")
+	}
+	if notes == "" {
+		return "-"
+	}
+	return notes
+}
+
+// availabilityLabel marks an item that cannot be ordered now, e.g.
+// "NOT NOW, from Sat 00:00".
+func availabilityLabel(status model.MenuItemStatus) string {
+	if status.NextAvailable.IsZero() {
+		return "NO LONGER SOLD"
+	}
+	return "NOT NOW, from " + status.NextAvailable.Format("Mon 15:04")
+}
+
+// stockLabel reads a stock level for the menu, e.g. "3 left" or
+// "SOLD OUT".
+func stockLabel(level model.StockLevel) string {
+	if level.SoldOut() {
+		return "SOLD OUT"
+	}
+	return fmt.Sprintf("%d left", level.Remaining)
+}
+
+// prependNote puts note before the menu notes, dropping the "-" placeholder.
+func prependNote(note, notes string) string {
+	if notes == "-" {
+		return note
+	}
+	return note + "; " + notes
+}
+
+func freeQtyLabel(freeQty int) string {
+	if freeQty == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d", freeQty)
+}
 
 // readline-aware readLine
 func readLine(rl *readline.Instance) (string, error) {
@@ -237,3 +747,83 @@ func readLine(rl *readline.Instance) (string, error) {
 	}
 	return strings.TrimSpace(s), nil
 }
+
+func memberLabel(memberID string, tier _memberModel.MemberTier) string {
+	if memberID == "" {
+		return "-"
+	}
+	return fmt.Sprintf("%s (%s)", memberID, tier)
+}
+
+func combinationLabel(codes []string) string {
+	if len(codes) == 0 {
+		return "no promotions"
+	}
+	return strings.Join(codes, " + ")
+}
+
+func (c *FoodShopControllerImpl) printAppliedPromotions(applied []model.AppliedPromotion) {
+	if len(applied) == 0 {
+		return
+	}
+
+	fmt.Fprintln(c.out, "\n--- Applied Promotions ---")
+	for _, promo := range applied {
+		fmt.Fprintf(c.out, "[%s] -%s\n", promo.Code, promo.Discount.String())
+		fmt.Fprintf(c.out, "  %s\n", promo.Explanation)
+	}
+}
+
+// printLineOptions lists the options chosen for an order line under it.
+func (c *FoodShopControllerImpl) printLineOptions(options []model.SelectedOption) {
+	for _, option := range options {
+		if option.PriceDelta == 0 {
+			fmt.Fprintf(c.out, "%-7s   + %s\n", "", option.Name)
+			continue
+		}
+		fmt.Fprintf(c.out, "%-7s   + %-22s %s\n", "", option.Name, signedAmount(option.PriceDelta))
+	}
+}
+
+// printMenuPrice notes the price version of a line once the item's price has changed.
+func (c *FoodShopControllerImpl) printMenuPrice(price model.PriceVersion) {
+	if price.Version <= 1 {
+		return
+	}
+	fmt.Fprintf(c.out, "%-7s   @ price v%d, %s from %s\n", "", price.Version, price.Price.String(), price.EffectiveFrom.Format("2006-01-02 15:04"))
+}
+
+// printTaxes shows the service charge and VAT added to the total, if the
+// quote was priced with any.
+func (c *FoodShopControllerImpl) printTaxes(tax model.TaxPolicy, preTax, serviceCharge, vat, grandTotal domain.Money) {
+	if !tax.Charges() {
+		return
+	}
+
+	fmt.Fprintf(c.out, "%-16s : %s\n", "Pre-tax", preTax.String())
+	fmt.Fprintf(c.out, "%-16s : %s (%s)\n", "Service Charge", serviceCharge.String(), tax.ServiceChargeRate)
+	fmt.Fprintf(c.out, "%-16s : %s (%s)\n", "VAT", vat.String(), tax.VATRate)
+	fmt.Fprintf(c.out, "%-16s : %s\n", "Grand Total", grandTotal.String())
+}
+
+// printAmountInWords spells out the amount to pay, in Thai and English,
+// as receipts show it.
+func (c *FoodShopControllerImpl) printAmountInWords(amount domain.Money) {
+	fmt.Fprintf(c.out, "%-16s : %s\n", "In words", amount.ThaiWords())
+	fmt.Fprintf(c.out, "%-16s   %s\n", "", amount.EnglishWords())
+}
+
+// printDisplayTotal shows the total in the customer's display currency
+// with the rate behind it; the THB total above is what gets paid.
+func (c *FoodShopControllerImpl) printDisplayTotal(display *model.DisplayTotal) {
+	if display == nil {
+		return
+	}
+
+	fmt.Fprintf(c.out, "%-16s : %s\n", "Total in "+string(display.Total.Currency), display.Total.String())
+	if display.RateUpdatedAt.IsZero() {
+		return
+	}
+	fmt.Fprintf(c.out, "  1 %s = %s THB, rate of %s (pay in THB)\n",
+		display.Total.Currency, display.Rate, display.RateUpdatedAt.Format("2006-01-02 15:04"))
+}
@@ -0,0 +1,75 @@
+package domain
+
+import "math/bits"
+
+// Allocate splits m over weights in proportion to them. Each part is
+// floored and the satang left over go one each to the parts with the
+// largest remainders, the earlier part first on a tie, so the parts always
+// add up to m. When no weight is positive all of m goes to the first part.
+// m and the weights must not be negative.
+func (m Money) Allocate(weights []Money) []Money {
+	return m.AllocateRounded(weights, RoundFloor)
+}
+
+// AllocateRounded is Allocate with each part first rounded with mode. If
+// the rounded parts do not add up to m, satang are added to the parts
+// with the largest remainders still rounded down, or taken back from the
+// parts with the smallest remainders that were rounded up, until they do.
+func (m Money) AllocateRounded(weights []Money, mode RoundingMode) []Money {
+	parts := make([]Money, len(weights))
+	if len(weights) == 0 {
+		return parts
+	}
+
+	var total uint64
+	for _, w := range weights {
+		total += uint64(w)
+	}
+	if total == 0 {
+		parts[0] = m
+		return parts
+	}
+
+	remainders := make([]uint64, len(weights))
+	roundedUp := make([]bool, len(weights))
+	left := m
+	for i, w := range weights {
+		hi, lo := bits.Mul64(uint64(m), uint64(w))
+		quo, rem := bits.Div64(hi, lo, total)
+		parts[i] = Money(quo)
+		remainders[i] = rem
+		if roundAway(quo, rem, total, true, mode) {
+			parts[i]++
+			roundedUp[i] = true
+		}
+		left -= parts[i]
+	}
+
+	for ; left > 0; left-- {
+		best := -1
+		for i, rem := range remainders {
+			if roundedUp[i] || rem == 0 {
+				continue
+			}
+			if best < 0 || rem > remainders[best] {
+				best = i
+			}
+		}
+		parts[best]++
+		roundedUp[best] = true
+	}
+	for ; left < 0; left++ {
+		best := -1
+		for i := len(remainders) - 1; i >= 0; i-- {
+			if !roundedUp[i] {
+				continue
+			}
+			if best < 0 || remainders[i] < remainders[best] {
+				best = i
+			}
+		}
+		parts[best]--
+		roundedUp[best] = false
+	}
+	return parts
+}
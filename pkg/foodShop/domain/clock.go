@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// Clock lets the pricing code ask for "now" without calling time.Now
+// directly, so tests can pin the time.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func SystemClock() Clock { return systemClock{} }
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+type fixedClock struct {
+	t time.Time
+}
+
+func FixedClock(t time.Time) Clock { return fixedClock{t: t} }
+
+func (c fixedClock) Now() time.Time { return c.t }
@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Currency is an ISO 4217 currency code. Settlement is always in THB;
+// other currencies are only used to show a converted total.
+type Currency string
+
+const (
+	CurrencyTHB Currency = "THB"
+	CurrencyUSD Currency = "USD"
+	CurrencyEUR Currency = "EUR"
+	CurrencyCNY Currency = "CNY"
+	CurrencyJPY Currency = "JPY"
+)
+
+// minorUnits is the number of decimals each supported currency is quoted
+// in, as listed in ISO 4217.
+var minorUnits = map[Currency]int{
+	CurrencyTHB: 2,
+	CurrencyUSD: 2,
+	CurrencyEUR: 2,
+	CurrencyCNY: 2,
+	CurrencyJPY: 0,
+}
+
+// ParseCurrency upper-cases code and checks it is a supported currency.
+func ParseCurrency(code string) (Currency, error) {
+	currency := Currency(strings.ToUpper(strings.TrimSpace(code)))
+	if !currency.Valid() {
+		return "", fmt.Errorf("unsupported currency %q", code)
+	}
+	return currency, nil
+}
+
+func (c Currency) Valid() bool {
+	_, ok := minorUnits[c]
+	return ok
+}
+
+// MinorUnits is how many decimals c has: 2 for THB (satang), 0 for JPY.
+func (c Currency) MinorUnits() int {
+	return minorUnits[c]
+}
+
+// Amount is money in any currency, counted in that currency's minor unit:
+// cents for USD, fen for CNY, whole yen for JPY.
+type Amount struct {
+	Minor    int64
+	Currency Currency
+}
+
+// String renders a with its currency's decimals, e.g. "3.27 USD" or
+// "452 JPY".
+func (a Amount) String() string {
+	return formatDecimal(a.Minor, a.Currency.MinorUnits()) + " " + string(a.Currency)
+}
+
+// amountJSON is how an Amount is written: the value as a decimal string
+// in the currency's precision, e.g. {"amount":"3.27","currency":"USD"}.
+type amountJSON struct {
+	Amount   string   `json:"amount"`
+	Currency Currency `json:"currency"`
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(amountJSON{
+		Amount:   formatDecimal(a.Minor, a.Currency.MinorUnits()),
+		Currency: a.Currency,
+	})
+}
+
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	var raw amountJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	currency, err := ParseCurrency(string(raw.Currency))
+	if err != nil {
+		return err
+	}
+	minor, err := parseDecimal(raw.Amount, currency.MinorUnits())
+	if err != nil {
+		return fmt.Errorf("invalid %s amount %q", currency, raw.Amount)
+	}
+	*a = Amount{Minor: minor, Currency: currency}
+	return nil
+}
+
+// ExchangeRate is the price of one unit of a currency in THB, kept to six
+// decimals: 36.5 THB per USD is 36_500_000. In JSON it is written as a
+// decimal string, e.g. "36.5".
+type ExchangeRate int64
+
+const exchangeRateDecimals = 6
+
+// ParseExchangeRate reads a positive THB-per-unit rate with up to six
+// decimals, e.g. "36.5" or "0.2415".
+func ParseExchangeRate(s string) (ExchangeRate, error) {
+	micros, err := parseDecimal(strings.TrimSpace(s), exchangeRateDecimals)
+	if errors.Is(err, ErrAmountOverflow) {
+		return 0, fmt.Errorf("invalid exchange rate %q: %w", s, err)
+	}
+	if err != nil || micros <= 0 {
+		return 0, fmt.Errorf("invalid exchange rate %q: use a number > 0 with at most %d decimals", s, exchangeRateDecimals)
+	}
+	return ExchangeRate(micros), nil
+}
+
+// String renders r without trailing zeros, e.g. "36.5".
+func (r ExchangeRate) String() string {
+	return strings.TrimSuffix(strings.TrimRight(formatDecimal(int64(r), exchangeRateDecimals), "0"), ".")
+}
+
+func (r ExchangeRate) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(r.String())), nil
+}
+
+// UnmarshalJSON accepts a decimal string ("36.5") or a JSON number (36.5).
+func (r *ExchangeRate) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+
+	rate, err := ParseExchangeRate(text)
+	if err != nil {
+		return err
+	}
+	*r = rate
+	return nil
+}
+
+// Convert turns the THB amount m into currency at rate THB per unit,
+// rounded to currency's minor unit with mode.
+func (m Money) Convert(currency Currency, rate ExchangeRate, mode RoundingMode) (Amount, error) {
+	if rate <= 0 {
+		return Amount{}, ErrDivisionByZero
+	}
+
+	// satang * 10^digits / 100 gives minor units at 1 THB per unit; the
+	// rate has six decimals, so scale by 10^(digits+4) and divide by rate.
+	scale := int64(math.Pow10(currency.MinorUnits() + exchangeRateDecimals - 2))
+	minor, err := mulDiv(int64(m), scale, int64(rate), mode)
+	if err != nil {
+		return Amount{}, err
+	}
+	return Amount{Minor: int64(minor), Currency: currency}, nil
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "fmt"
+import (
+	"errors"
+	"math"
+)
 
 // Money stored as satang to avoid float issues
 type Money int64
@@ -11,16 +14,42 @@ func (m Money) Add(x Money) Money { return m + x }
 func (m Money) Sub(x Money) Money { return m - x }
 func (m Money) MulInt(n int) Money { return m * Money(n) }
 
+// ErrAmountOverflow is returned when a result does not fit in a Money.
+var ErrAmountOverflow = errors.New("amount overflow")
+
+// AddChecked is m + x, or ErrAmountOverflow instead of wrapping around.
+func (m Money) AddChecked(x Money) (Money, error) {
+	if (x > 0 && m > math.MaxInt64-x) || (x < 0 && m < math.MinInt64-x) {
+		return 0, ErrAmountOverflow
+	}
+	return m + x, nil
+}
+
+// SubChecked is m - x, or ErrAmountOverflow instead of wrapping around.
+func (m Money) SubChecked(x Money) (Money, error) {
+	if (x < 0 && m > math.MaxInt64+x) || (x > 0 && m < math.MinInt64+x) {
+		return 0, ErrAmountOverflow
+	}
+	return m - x, nil
+}
+
+// MulIntChecked is m * n, or ErrAmountOverflow instead of wrapping around.
+func (m Money) MulIntChecked(n int) (Money, error) {
+	if m == 0 || n == 0 {
+		return 0, nil
+	}
+	product := m * Money(n)
+	if product/Money(n) != m || (n == -1 && m == math.MinInt64) {
+		return 0, ErrAmountOverflow
+	}
+	return product, nil
+}
+
 // Percent uses integer arithmetic => floor rounding automatically
 func (m Money) Percent(p int64) Money {
 	return Money(int64(m) * p / 100)
 }
 
 func (m Money) String() string {
-	baht := int64(m) / 100
-	satang := int64(m) % 100
-	if satang < 0 {
-		satang = -satang
-	}
-	return fmt.Sprintf("%d.%02d THB", baht, satang)
+	return m.Decimal() + " THB"
 }
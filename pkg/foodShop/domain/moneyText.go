@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var errInvalidDecimal = errors.New("invalid decimal")
+
+// ParseMoney reads a baht amount with at most two decimals, e.g. "50",
+// "50.5", "-0.25" or "1250.00 THB". Anything else, including a third
+// decimal, is an error rather than a silently rounded amount.
+func ParseMoney(s string) (Money, error) {
+	text := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), string(CurrencyTHB)))
+	satang, err := parseDecimal(text, CurrencyTHB.MinorUnits())
+	if errors.Is(err, ErrAmountOverflow) {
+		return 0, fmt.Errorf("invalid amount %q: %w", s, err)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q: use baht with at most two decimals", s)
+	}
+	return Money(satang), nil
+}
+
+// parseDecimal reads an optionally negative decimal with at most digits
+// decimals as an integer count of 10^-digits, e.g. "12.5" with 2 digits
+// is 1250.
+func parseDecimal(text string, digits int) (int64, error) {
+	negative := strings.HasPrefix(text, "-")
+	whole, frac, hasFrac := strings.Cut(strings.TrimPrefix(text, "-"), ".")
+	if whole == "" || !digitsOnly(whole) || (hasFrac && (frac == "" || len(frac) > digits || !digitsOnly(frac))) {
+		return 0, errInvalidDecimal
+	}
+
+	var fraction uint64
+	if hasFrac {
+		fraction, _ = strconv.ParseUint(frac+strings.Repeat("0", digits-len(frac)), 10, 64)
+	}
+	scale := uint64(math.Pow10(digits))
+	units, err := strconv.ParseUint(whole, 10, 64)
+	if err != nil || units > (math.MaxInt64-fraction)/scale {
+		return 0, ErrAmountOverflow
+	}
+
+	value := int64(units*scale + fraction)
+	if negative {
+		value = -value
+	}
+	return value, nil
+}
+
+func digitsOnly(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// formatDecimal renders value counted in 10^-digits as a plain decimal,
+// e.g. 1250 with 2 digits is "12.50".
+func formatDecimal(value int64, digits int) string {
+	sign := ""
+	magnitude := uint64(value)
+	if value < 0 {
+		sign = "-"
+		magnitude = -magnitude
+	}
+	if digits == 0 {
+		return fmt.Sprintf("%s%d", sign, magnitude)
+	}
+	scale := uint64(math.Pow10(digits))
+	return fmt.Sprintf("%s%d.%0*d", sign, magnitude/scale, digits, magnitude%scale)
+}
+
+// Decimal renders m as a baht amount with two decimals and no unit, e.g.
+// "50.00" or "-0.25"; ParseMoney reads it back.
+func (m Money) Decimal() string {
+	return formatDecimal(int64(m), CurrencyTHB.MinorUnits())
+}
+
+func (m Money) MarshalText() ([]byte, error) {
+	return []byte(m.Decimal()), nil
+}
+
+func (m *Money) UnmarshalText(text []byte) error {
+	amount, err := ParseMoney(string(text))
+	if err != nil {
+		return err
+	}
+	*m = amount
+	return nil
+}
+
+// MarshalJSON writes m as a decimal string, e.g. "50.00", so the amount
+// keeps its meaning outside Go.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(m.Decimal())), nil
+}
+
+// UnmarshalJSON accepts a decimal string ("50.00") or a JSON number (50.5),
+// both read with ParseMoney. Like the standard library, null leaves m
+// unchanged.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+	return m.UnmarshalText([]byte(text))
+}
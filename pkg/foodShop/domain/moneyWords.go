@@ -0,0 +1,151 @@
+package domain
+
+import "strings"
+
+var thaiDigits = [10]string{"ศูนย์", "หนึ่ง", "สอง", "สาม", "สี่", "ห้า", "หก", "เจ็ด", "แปด", "เก้า"}
+
+// thaiPlaces names the positions inside a group of six digits; ล้าน
+// (million) joins the groups.
+var thaiPlaces = [6]string{"", "สิบ", "ร้อย", "พัน", "หมื่น", "แสน"}
+
+// ThaiWords spells the amount the way Thai receipts do, e.g. 126.00 is
+// "หนึ่งร้อยยี่สิบหกบาทถ้วน" and 68.40 is "หกสิบแปดบาทสี่สิบสตางค์".
+// A one in the units place after other digits is เอ็ด (101 หนึ่งร้อยเอ็ด),
+// a two in the tens place is ยี่ (20 ยี่สิบ) and a one there is just สิบ.
+func (m Money) ThaiWords() string {
+	if m == 0 {
+		return "ศูนย์บาทถ้วน"
+	}
+
+	abs := absUint(int64(m))
+	baht, satang := abs/100, abs%100
+
+	var b strings.Builder
+	if m < 0 {
+		b.WriteString("ลบ")
+	}
+	if baht > 0 {
+		b.WriteString(thaiNumber(baht))
+		b.WriteString("บาท")
+	}
+	if satang == 0 {
+		b.WriteString("ถ้วน")
+	} else {
+		b.WriteString(thaiNumber(satang))
+		b.WriteString("สตางค์")
+	}
+	return b.String()
+}
+
+// thaiNumber spells n > 0 in Thai, six digits per ล้าน.
+func thaiNumber(n uint64) string {
+	var b strings.Builder
+	if n >= 1_000_000 {
+		b.WriteString(thaiNumber(n / 1_000_000))
+		b.WriteString("ล้าน")
+	}
+
+	group := n % 1_000_000
+	for place := 5; place >= 0; place-- {
+		digit := group / pow10u(place) % 10
+		if digit == 0 {
+			continue
+		}
+		switch {
+		case place == 1 && digit == 1:
+			// สิบ, never หนึ่งสิบ.
+		case place == 1 && digit == 2:
+			b.WriteString("ยี่")
+		case place == 0 && digit == 1 && n > 1:
+			b.WriteString("เอ็ด")
+			continue
+		default:
+			b.WriteString(thaiDigits[digit])
+		}
+		b.WriteString(thaiPlaces[place])
+	}
+	return b.String()
+}
+
+var englishOnes = [20]string{
+	"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+	"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen",
+}
+
+var englishTens = [10]string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+
+var englishScales = []string{"", "thousand", "million", "billion", "trillion", "quadrillion"}
+
+// EnglishWords spells the amount in English for receipts, e.g. 126.00 is
+// "One hundred twenty-six baht only" and 68.40 is "Sixty-eight baht and
+// forty satang".
+func (m Money) EnglishWords() string {
+	abs := absUint(int64(m))
+	baht, satang := abs/100, abs%100
+
+	var parts []string
+	if m < 0 {
+		parts = append(parts, "minus")
+	}
+	if baht > 0 || satang == 0 {
+		parts = append(parts, englishNumber(baht), "baht")
+	}
+	switch {
+	case satang == 0:
+		parts = append(parts, "only")
+	case baht > 0:
+		parts = append(parts, "and", englishNumber(satang), "satang")
+	default:
+		parts = append(parts, englishNumber(satang), "satang")
+	}
+
+	words := strings.Join(parts, " ")
+	return strings.ToUpper(words[:1]) + words[1:]
+}
+
+// englishNumber spells n in groups of three digits, without "and":
+// 126 is "one hundred twenty-six".
+func englishNumber(n uint64) string {
+	if n == 0 {
+		return englishOnes[0]
+	}
+
+	var groups []string
+	for scale := 0; n > 0; scale++ {
+		if group := n % 1000; group > 0 {
+			words := englishHundreds(group)
+			if englishScales[scale] != "" {
+				words += " " + englishScales[scale]
+			}
+			groups = append([]string{words}, groups...)
+		}
+		n /= 1000
+	}
+	return strings.Join(groups, " ")
+}
+
+func englishHundreds(n uint64) string {
+	var parts []string
+	if n >= 100 {
+		parts = append(parts, englishOnes[n/100], "hundred")
+		n %= 100
+	}
+	switch {
+	case n == 0:
+	case n < 20:
+		parts = append(parts, englishOnes[n])
+	case n%10 == 0:
+		parts = append(parts, englishTens[n/10])
+	default:
+		parts = append(parts, englishTens[n/10]+"-"+englishOnes[n%10])
+	}
+	return strings.Join(parts, " ")
+}
+
+func pow10u(n int) uint64 {
+	p := uint64(1)
+	for ; n > 0; n-- {
+		p *= 10
+	}
+	return p
+}
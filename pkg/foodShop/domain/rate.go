@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// ErrDivisionByZero is returned when dividing by a zero rate.
+var ErrDivisionByZero = errors.New("division by zero")
+
+// Rate is a percentage in basis points: 1 bp = 0.01%, so 12.5% is 1250.
+// In JSON a Rate is written as a percent number, e.g. 12.5.
+type Rate int64
+
+// BasisPointsPerWhole is the Rate of 100%.
+const BasisPointsPerWhole Rate = 10000
+
+func PercentRate(p int64) Rate  { return Rate(p * 100) }
+func BasisPoints(bp int64) Rate { return Rate(bp) }
+
+// ParseRate reads a percentage with up to two decimals, e.g. "12.5" or
+// "2.25%".
+func ParseRate(s string) (Rate, error) {
+	text := strings.TrimSuffix(strings.TrimSpace(s), "%")
+	whole, frac, hasFrac := strings.Cut(text, ".")
+	if hasFrac && (len(frac) == 0 || len(frac) > 2) {
+		return 0, fmt.Errorf("invalid rate %q: use at most two decimals", s)
+	}
+
+	negative := strings.HasPrefix(whole, "-")
+	percent, err := strconv.ParseInt(strings.TrimPrefix(whole, "-"), 10, 64)
+	if err != nil || percent > math.MaxInt64/100 {
+		return 0, fmt.Errorf("invalid rate %q", s)
+	}
+
+	var hundredths int64
+	if hasFrac {
+		hundredths, err = strconv.ParseInt(frac+strings.Repeat("0", 2-len(frac)), 10, 64)
+		if err != nil || hundredths < 0 {
+			return 0, fmt.Errorf("invalid rate %q", s)
+		}
+	}
+
+	bp := percent*100 + hundredths
+	if negative {
+		bp = -bp
+	}
+	return Rate(bp), nil
+}
+
+// String renders r as a percentage without trailing zeros, e.g. "12.5%".
+func (r Rate) String() string {
+	sign := ""
+	bp := int64(r)
+	if bp < 0 {
+		sign = "-"
+		bp = -bp
+	}
+
+	text := strconv.FormatInt(bp/100, 10)
+	if frac := bp % 100; frac != 0 {
+		text += strings.TrimRight(fmt.Sprintf(".%02d", frac), "0")
+	}
+	return sign + text + "%"
+}
+
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(strings.TrimSuffix(r.String(), "%")), nil
+}
+
+// UnmarshalJSON accepts a percent number (12.5) or string ("12.5%").
+// Like Money, null leaves r unchanged.
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+
+	rate, err := ParseRate(text)
+	if err != nil {
+		return err
+	}
+	*r = rate
+	return nil
+}
+
+// MulRate is r of m rounded with mode, e.g. 12.5% of 100.00 THB is 12.50
+// THB. It fails with ErrAmountOverflow instead of wrapping around.
+func (m Money) MulRate(r Rate, mode RoundingMode) (Money, error) {
+	return mulDiv(int64(m), int64(r), int64(BasisPointsPerWhole), mode)
+}
+
+// DivRate is the amount that m is r of, rounded with mode: the inverse of
+// MulRate. It fails with ErrDivisionByZero for a zero rate and with
+// ErrAmountOverflow when the result does not fit.
+func (m Money) DivRate(r Rate, mode RoundingMode) (Money, error) {
+	if r == 0 {
+		return 0, ErrDivisionByZero
+	}
+	return mulDiv(int64(m), int64(BasisPointsPerWhole), int64(r), mode)
+}
+
+// mulDiv is a * b / d rounded with mode, computed in 128 bits so only a
+// result outside the int64 range overflows.
+func mulDiv(a, b, d int64, mode RoundingMode) (Money, error) {
+	if d == 0 {
+		return 0, ErrDivisionByZero
+	}
+
+	positive := (a < 0) == (b < 0) == (d >= 0) || a == 0 || b == 0
+	hi, lo := bits.Mul64(absUint(a), absUint(b))
+	den := absUint(d)
+	if hi >= den {
+		return 0, ErrAmountOverflow
+	}
+	quo, rem := bits.Div64(hi, lo, den)
+	if roundAway(quo, rem, den, positive, mode) {
+		quo++
+	}
+
+	if positive {
+		if quo > math.MaxInt64 {
+			return 0, ErrAmountOverflow
+		}
+		return Money(quo), nil
+	}
+	if quo > 1<<63 {
+		return 0, ErrAmountOverflow
+	}
+	return Money(-int64(quo-1) - 1), nil
+}
+
+func absUint(v int64) uint64 {
+	if v < 0 {
+		return uint64(-(v + 1)) + 1
+	}
+	return uint64(v)
+}
@@ -0,0 +1,81 @@
+package domain
+
+// RoundingMode says how an amount that falls between two satang is rounded.
+type RoundingMode string
+
+const (
+	// RoundFloor rounds toward negative infinity, like Percent.
+	RoundFloor RoundingMode = "FLOOR"
+	// RoundCeiling rounds toward positive infinity.
+	RoundCeiling RoundingMode = "CEILING"
+	// RoundHalfUp rounds to the nearest satang, halves away from zero.
+	RoundHalfUp RoundingMode = "HALF_UP"
+	// RoundHalfEven rounds to the nearest satang, halves to the even one
+	// (banker's rounding).
+	RoundHalfEven RoundingMode = "HALF_EVEN"
+)
+
+func (r RoundingMode) Valid() bool {
+	switch r {
+	case RoundFloor, RoundCeiling, RoundHalfUp, RoundHalfEven:
+		return true
+	default:
+		return false
+	}
+}
+
+// PercentRounded is p percent of m rounded with mode.
+func (m Money) PercentRounded(p int64, mode RoundingMode) Money {
+	return Money(divRound(int64(m)*p, 100, mode))
+}
+
+// Div divides m by n (n > 0) rounded with mode.
+func (m Money) Div(n int64, mode RoundingMode) Money {
+	return Money(divRound(int64(m), n, mode))
+}
+
+// RoundTo rounds m to a multiple of unit (unit > 0), e.g. RoundTo(25,
+// RoundHalfUp) rounds to the nearest 0.25 THB for cash payments. A unit of
+// zero or less leaves m unchanged.
+func (m Money) RoundTo(unit Money, mode RoundingMode) Money {
+	if unit <= 0 {
+		return m
+	}
+	return Money(divRound(int64(m), int64(unit), mode)) * unit
+}
+
+// divRound is n / d (d > 0) rounded with mode. An unknown mode floors.
+func divRound(n, d int64, mode RoundingMode) int64 {
+	q, r := n/d, n%d
+	if r == 0 {
+		return q
+	}
+
+	sign := int64(1)
+	if r < 0 {
+		sign = -1
+		r = -r
+	}
+	if roundAway(uint64(q*sign), uint64(r), uint64(d), sign > 0, mode) {
+		return q + sign
+	}
+	return q
+}
+
+// roundAway decides whether a result whose magnitude is q plus rem/den
+// moves one step away from zero. positive is the sign of the exact result.
+func roundAway(q, rem, den uint64, positive bool, mode RoundingMode) bool {
+	if rem == 0 {
+		return false
+	}
+	switch mode {
+	case RoundCeiling:
+		return positive
+	case RoundHalfUp:
+		return rem >= den-rem
+	case RoundHalfEven:
+		return rem > den-rem || (rem == den-rem && q%2 == 1)
+	default:
+		return !positive
+	}
+}
@@ -0,0 +1,23 @@
+package exception
+
+import (
+	"fmt"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+)
+
+// AmountOverflowError is returned when an order amount no longer fits in
+// Money, or goes over the maximum order value when Limit is set.
+type AmountOverflowError struct {
+	Step  string
+	Limit domain.Money
+}
+
+func (e *AmountOverflowError) Error() string {
+	if e.Limit > 0 {
+		return fmt.Sprintf("Error: %s is over the maximum order value of %s", e.Step, e.Limit)
+	}
+	return fmt.Sprintf("Error: amount overflow in %s", e.Step)
+}
+
+func (e *AmountOverflowError) Unwrap() error { return domain.ErrAmountOverflow }
@@ -0,0 +1,16 @@
+package exception
+
+import "fmt"
+
+type CouponExhaustedError struct {
+	Code        string
+	Limit       int
+	PerCustomer bool
+}
+
+func (e *CouponExhaustedError) Error() string {
+	if e.PerCustomer {
+		return fmt.Sprintf("Error: coupon %s already used %d time(s) by this customer", e.Code, e.Limit)
+	}
+	return fmt.Sprintf("Error: coupon %s has reached its redemption limit (%d)", e.Code, e.Limit)
+}
@@ -0,0 +1,12 @@
+package exception
+
+import "fmt"
+
+type CouponNotApplicableError struct {
+	Code   string
+	Reason string
+}
+
+func (e *CouponNotApplicableError) Error() string {
+	return fmt.Sprintf("Error: coupon %s cannot be applied: %s", e.Code, e.Reason)
+}
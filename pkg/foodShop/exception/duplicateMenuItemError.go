@@ -0,0 +1,11 @@
+package exception
+
+import "fmt"
+
+type DuplicateMenuItemError struct {
+	Code string
+}
+
+func (e *DuplicateMenuItemError) Error() string {
+	return fmt.Sprintf("Error: menu item code %s is already in use", e.Code)
+}
@@ -0,0 +1,15 @@
+package exception
+
+import (
+	"fmt"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+)
+
+type ExchangeRateNotFoundError struct {
+	Currency domain.Currency
+}
+
+func (e *ExchangeRateNotFoundError) Error() string {
+	return fmt.Sprintf("Error: no exchange rate for %s", e.Currency)
+}
@@ -0,0 +1,15 @@
+package exception
+
+import (
+	"fmt"
+	"time"
+)
+
+type ExpiredCouponError struct {
+	Code      string
+	ExpiredAt time.Time
+}
+
+func (e *ExpiredCouponError) Error() string {
+	return fmt.Sprintf("Error: coupon %s expired at %s", e.Code, e.ExpiredAt.Format("2006-01-02 15:04"))
+}
@@ -0,0 +1,15 @@
+package exception
+
+import (
+	"fmt"
+	"time"
+)
+
+type ExpiredMemberError struct {
+	ID        string
+	ExpiredAt time.Time
+}
+
+func (e *ExpiredMemberError) Error() string {
+	return fmt.Sprintf("Error: membership %s expired at %s", e.ID, e.ExpiredAt.Format("2006-01-02"))
+}
@@ -0,0 +1,12 @@
+package exception
+
+import "fmt"
+
+type InvalidBuyerError struct {
+	Field  string
+	Reason string
+}
+
+func (e *InvalidBuyerError) Error() string {
+	return fmt.Sprintf("Error: invalid buyer %s: %s", e.Field, e.Reason)
+}
@@ -0,0 +1,14 @@
+package exception
+
+import "fmt"
+
+type InvalidCouponError struct {
+	Code string
+}
+
+func (e *InvalidCouponError) Error() string {
+	if e.Code == "" {
+		return "Error: invalid coupon code. Please provide a non-empty coupon code."
+	}
+	return fmt.Sprintf("Error: invalid coupon code: %s", e.Code)
+}
@@ -0,0 +1,15 @@
+package exception
+
+import "fmt"
+
+type InvalidExchangeRateConfigError struct {
+	Currency string
+	Reason   string
+}
+
+func (e *InvalidExchangeRateConfigError) Error() string {
+	if e.Currency == "" {
+		return fmt.Sprintf("Error: invalid exchange rate config: %s", e.Reason)
+	}
+	return fmt.Sprintf("Error: invalid exchange rate config %q: %s", e.Currency, e.Reason)
+}
@@ -0,0 +1,15 @@
+package exception
+
+import "fmt"
+
+type InvalidMenuItemError struct {
+	Code   string
+	Reason string
+}
+
+func (e *InvalidMenuItemError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("Error: invalid menu item: %s", e.Reason)
+	}
+	return fmt.Sprintf("Error: invalid menu item %s: %s", e.Code, e.Reason)
+}
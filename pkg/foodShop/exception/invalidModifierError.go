@@ -0,0 +1,16 @@
+package exception
+
+import "fmt"
+
+// InvalidModifierError rejects the options chosen for a menu item. Group
+// and Option name the group or option at fault when there is one.
+type InvalidModifierError struct {
+	Code   string
+	Group  string
+	Option string
+	Reason string
+}
+
+func (e *InvalidModifierError) Error() string {
+	return fmt.Sprintf("Error: invalid options for %s: %s", e.Code, e.Reason)
+}
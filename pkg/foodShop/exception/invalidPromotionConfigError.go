@@ -0,0 +1,15 @@
+package exception
+
+import "fmt"
+
+type InvalidPromotionConfigError struct {
+	Code   string
+	Reason string
+}
+
+func (e *InvalidPromotionConfigError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("Error: invalid promotion config: %s", e.Reason)
+	}
+	return fmt.Sprintf("Error: invalid promotion config %q: %s", e.Code, e.Reason)
+}
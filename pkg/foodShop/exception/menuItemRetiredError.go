@@ -0,0 +1,15 @@
+package exception
+
+import (
+	"fmt"
+	"time"
+)
+
+type MenuItemRetiredError struct {
+	Code      string
+	RetiredAt time.Time
+}
+
+func (e *MenuItemRetiredError) Error() string {
+	return fmt.Sprintf("Error: menu item %s was retired at %s", e.Code, e.RetiredAt.Format("2006-01-02 15:04"))
+}
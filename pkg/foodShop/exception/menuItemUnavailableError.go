@@ -0,0 +1,23 @@
+package exception
+
+import (
+	"fmt"
+	"time"
+)
+
+// MenuItemUnavailableError rejects an item ordered outside its
+// availability. NextAvailable is zero when the item will not be sold
+// again.
+type MenuItemUnavailableError struct {
+	Code          string
+	Availability  string
+	NextAvailable time.Time
+}
+
+func (e *MenuItemUnavailableError) Error() string {
+	if e.NextAvailable.IsZero() {
+		return fmt.Sprintf("Error: %s is no longer available (%s)", e.Code, e.Availability)
+	}
+	return fmt.Sprintf("Error: %s is not available right now (%s); next available %s",
+		e.Code, e.Availability, e.NextAvailable.Format("Mon 2006-01-02 15:04"))
+}
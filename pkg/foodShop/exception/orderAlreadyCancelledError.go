@@ -0,0 +1,15 @@
+package exception
+
+import (
+	"fmt"
+	"time"
+)
+
+type OrderAlreadyCancelledError struct {
+	OrderNo     int
+	CancelledAt time.Time
+}
+
+func (e *OrderAlreadyCancelledError) Error() string {
+	return fmt.Sprintf("Error: order #%d was already cancelled at %s", e.OrderNo, e.CancelledAt.Format("2006-01-02 15:04"))
+}
@@ -0,0 +1,12 @@
+package exception
+
+import "fmt"
+
+type OrderNotCancellableError struct {
+	OrderNo int
+	Reason  string
+}
+
+func (e *OrderNotCancellableError) Error() string {
+	return fmt.Sprintf("Error: order #%d cannot be cancelled: %s", e.OrderNo, e.Reason)
+}
@@ -0,0 +1,11 @@
+package exception
+
+import "fmt"
+
+type OrderNotFoundError struct {
+	OrderNo int
+}
+
+func (e *OrderNotFoundError) Error() string {
+	return fmt.Sprintf("Error: order #%d not found", e.OrderNo)
+}
@@ -0,0 +1,16 @@
+package exception
+
+import "fmt"
+
+type OutOfStockError struct {
+	Code      string
+	Requested int
+	Remaining int
+}
+
+func (e *OutOfStockError) Error() string {
+	if e.Remaining <= 0 {
+		return fmt.Sprintf("Error: %s is sold out", e.Code)
+	}
+	return fmt.Sprintf("Error: only %d %s left, %d requested", e.Remaining, e.Code, e.Requested)
+}
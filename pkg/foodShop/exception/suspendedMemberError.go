@@ -0,0 +1,11 @@
+package exception
+
+import "fmt"
+
+type SuspendedMemberError struct {
+	ID string
+}
+
+func (e *SuspendedMemberError) Error() string {
+	return fmt.Sprintf("Error: membership %s is suspended", e.ID)
+}
@@ -0,0 +1,12 @@
+package exception
+
+import "fmt"
+
+type TaxInvoiceAlreadyIssuedError struct {
+	OrderNo int
+	Number  string
+}
+
+func (e *TaxInvoiceAlreadyIssuedError) Error() string {
+	return fmt.Sprintf("Error: order #%d already has tax invoice %s", e.OrderNo, e.Number)
+}
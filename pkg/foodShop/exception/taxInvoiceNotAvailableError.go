@@ -0,0 +1,12 @@
+package exception
+
+import "fmt"
+
+type TaxInvoiceNotAvailableError struct {
+	OrderNo int
+	Reason  string
+}
+
+func (e *TaxInvoiceNotAvailableError) Error() string {
+	return fmt.Sprintf("Error: cannot issue a tax invoice for order #%d: %s", e.OrderNo, e.Reason)
+}
@@ -0,0 +1,15 @@
+package exception
+
+import "fmt"
+
+type TaxInvoiceNotFoundError struct {
+	Number  string
+	OrderNo int
+}
+
+func (e *TaxInvoiceNotFoundError) Error() string {
+	if e.Number == "" {
+		return fmt.Sprintf("Error: no tax invoice for order #%d", e.OrderNo)
+	}
+	return fmt.Sprintf("Error: tax invoice not found: %s", e.Number)
+}
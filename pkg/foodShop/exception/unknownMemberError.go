@@ -0,0 +1,11 @@
+package exception
+
+import "fmt"
+
+type UnknownMemberError struct {
+	ID string
+}
+
+func (e *UnknownMemberError) Error() string {
+	return fmt.Sprintf("Error: unknown member: %s", e.ID)
+}
@@ -0,0 +1,11 @@
+package exception
+
+import "fmt"
+
+type UnsupportedCurrencyError struct {
+	Code string
+}
+
+func (e *UnsupportedCurrencyError) Error() string {
+	return fmt.Sprintf("Error: unsupported currency: %s", e.Code)
+}
@@ -0,0 +1,10 @@
+package model
+
+import "github.com/TewApirat/food-shop/pkg/foodShop/domain"
+
+// ComboPolicy sells Items (code -> qty) together for Price, e.g. any RED
+// plus any BLUE for 70 THB.
+type ComboPolicy struct {
+	Items map[MenuItemCode]int
+	Price domain.Money
+}
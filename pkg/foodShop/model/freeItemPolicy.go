@@ -0,0 +1,15 @@
+package model
+
+// FreeItemPolicy gives FreeQty units free for every BuyQty units of the
+// eligible codes. Without RewardCodes the free units are the cheapest of
+// every BuyQty+FreeQty eligible units ("buy 3 get 1", "every 3rd free").
+// With RewardCodes every BuyQty eligible units frees the cheapest FreeQty
+// reward units instead, and AutoAddReward puts missing reward units into
+// the order.
+type FreeItemPolicy struct {
+	EligibleCodes map[MenuItemCode]bool
+	BuyQty        int
+	FreeQty       int
+	RewardCodes   map[MenuItemCode]bool
+	AutoAddReward MenuItemCode
+}
@@ -1,11 +1,224 @@
 package model
 
-import "github.com/TewApirat/food-shop/pkg/foodShop/domain"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+)
 
 type MenuItemCode string
 
+// MenuCategory groups the menu on screen.
+type MenuCategory string
+
+const (
+	MenuCategorySet     MenuCategory = "SET"
+	MenuCategoryDrink   MenuCategory = "DRINK"
+	MenuCategoryDessert MenuCategory = "DESSERT"
+)
+
+// MenuCategories lists the categories in the order the menu shows them.
+func MenuCategories() []MenuCategory {
+	return []MenuCategory{MenuCategorySet, MenuCategoryDrink, MenuCategoryDessert}
+}
+
+// Title is the heading the category is shown under.
+func (c MenuCategory) Title() string {
+	switch c {
+	case MenuCategorySet:
+		return "Sets"
+	case MenuCategoryDrink:
+		return "Drinks"
+	case MenuCategoryDessert:
+		return "Desserts"
+	case "":
+		return "Other"
+	default:
+		return string(c)
+	}
+}
+
+// Rank orders categories as MenuCategories does; anything else sorts
+// after them.
+func (c MenuCategory) Rank() int {
+	for i, category := range MenuCategories() {
+		if c == category {
+			return i
+		}
+	}
+	return len(MenuCategories())
+}
+
+// MenuTag highlights an item on the menu.
+type MenuTag string
+
+const (
+	MenuTagSpicy      MenuTag = "SPICY"
+	MenuTagNew        MenuTag = "NEW"
+	MenuTagBestseller MenuTag = "BESTSELLER"
+)
+
+// MenuTags lists the known tags.
+func MenuTags() []MenuTag {
+	return []MenuTag{MenuTagSpicy, MenuTagNew, MenuTagBestseller}
+}
+
+// DietaryFlag tells customers what an item is suitable for or contains.
+type DietaryFlag string
+
+const (
+	DietaryVegetarian      DietaryFlag = "VEGETARIAN"
+	DietaryHalal           DietaryFlag = "HALAL"
+	DietaryContainsPeanuts DietaryFlag = "CONTAINS_PEANUTS"
+)
+
+// Label is the flag as printed on the menu, e.g. "contains peanuts".
+func (f DietaryFlag) Label() string {
+	return strings.ToLower(strings.ReplaceAll(string(f), "_", " "))
+}
+
+// MenuItem is one orderable item. Price is its opening price and
+// PriceChanges the later prices, oldest first; use PriceAt for the price
+// at a given moment. Items are listed by category, then DisplayOrder
+// (lowest first), then code. Modifiers are the option groups
+// a customer chooses from when ordering it, in the order they are shown.
+// Availability limits when the item can be ordered; nil means always.
+// A retired item is off the menu but still found by code, so orders in the
+// history keep resolving.
 type MenuItem struct {
-	Code  MenuItemCode
-	Name  string
-	Price domain.Money
+	Code         MenuItemCode    `json:"code"`
+	Name         string          `json:"name"`
+	Price        domain.Money    `json:"price"`
+	PriceChanges []PriceVersion  `json:"priceChanges,omitempty"`
+	Category     MenuCategory    `json:"category,omitempty"`
+	Description  string          `json:"description,omitempty"`
+	Tags         []MenuTag       `json:"tags,omitempty"`
+	Dietary      []DietaryFlag   `json:"dietary,omitempty"`
+	DisplayOrder int             `json:"displayOrder,omitempty"`
+	Modifiers    []ModifierGroup `json:"modifiers,omitempty"`
+	Availability *Schedule       `json:"availability,omitempty"`
+	RetiredAt    time.Time       `json:"retiredAt,omitzero"`
+}
+
+func (m MenuItem) Retired() bool {
+	return !m.RetiredAt.IsZero()
+}
+
+// MenuItemUpdate changes the name and/or price of a menu item; nil fields
+// are left as they are. A new price becomes the next price version,
+// taking effect at EffectiveFrom (now when zero); the name changes at
+// once.
+type MenuItemUpdate struct {
+	Code          MenuItemCode  `json:"code"`
+	Name          *string       `json:"name,omitempty"`
+	Price         *domain.Money `json:"price,omitempty"`
+	EffectiveFrom time.Time     `json:"effectiveFrom,omitzero"`
+}
+
+// Clone copies the item so the copy's prices, tags, dietary flags,
+// modifiers and availability can be changed without touching the
+// original.
+func (m MenuItem) Clone() MenuItem {
+	m.PriceChanges = append([]PriceVersion(nil), m.PriceChanges...)
+	m.Tags = append([]MenuTag(nil), m.Tags...)
+	m.Dietary = append([]DietaryFlag(nil), m.Dietary...)
+	if m.Modifiers != nil {
+		modifiers := make([]ModifierGroup, len(m.Modifiers))
+		for i, group := range m.Modifiers {
+			modifiers[i] = group.Clone()
+		}
+		m.Modifiers = modifiers
+	}
+	if m.Availability != nil {
+		availability := *m.Availability
+		availability.Days = append([]string(nil), availability.Days...)
+		m.Availability = &availability
+	}
+	return m
+}
+
+func (m MenuItem) AvailableAt(t time.Time) (bool, error) {
+	if m.Availability == nil {
+		return true, nil
+	}
+	return m.Availability.ActiveAt(t)
+}
+
+// NextAvailable returns when the item can next be ordered after t, in its
+// schedule's timezone, and false if it never can again.
+func (m MenuItem) NextAvailable(t time.Time) (time.Time, bool, error) {
+	if m.Availability == nil {
+		return t, true, nil
+	}
+	return m.Availability.NextActiveAfter(t)
+}
+
+// MenuItemStatus is a menu item as listed to customers at a given moment.
+// NextAvailable is set when the item cannot be ordered now but will be
+// again. CurrentPrice is the price in effect and NextPrice the next price
+// change, nil when none is scheduled.
+type MenuItemStatus struct {
+	MenuItem
+	Available     bool
+	NextAvailable time.Time
+	CurrentPrice  PriceVersion
+	NextPrice     *PriceVersion
+}
+
+func (m MenuItem) HasTag(tag MenuTag) bool {
+	for _, t := range m.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// MenuFilter picks menu items by category and tag. Empty fields match
+// every item; retired items only match with IncludeRetired.
+type MenuFilter struct {
+	Category       MenuCategory
+	Tag            MenuTag
+	IncludeRetired bool
+}
+
+func (f MenuFilter) Matches(item MenuItem) bool {
+	if item.Retired() && !f.IncludeRetired {
+		return false
+	}
+	if f.Category != "" && item.Category != f.Category {
+		return false
+	}
+	if f.Tag != "" && !item.HasTag(f.Tag) {
+		return false
+	}
+	return true
+}
+
+// ParseMenuFilter reads space-separated words, each a category or a tag
+// in any case, e.g. "drink new". An empty string matches everything.
+func ParseMenuFilter(s string) (MenuFilter, error) {
+	var filter MenuFilter
+	for _, word := range strings.Fields(strings.ToUpper(s)) {
+		switch {
+		case MenuCategory(word).Rank() < len(MenuCategories()):
+			filter.Category = MenuCategory(word)
+		case isMenuTag(MenuTag(word)):
+			filter.Tag = MenuTag(word)
+		default:
+			return MenuFilter{}, fmt.Errorf("unknown category or tag %q", word)
+		}
+	}
+	return filter, nil
+}
+
+func isMenuTag(tag MenuTag) bool {
+	for _, t := range MenuTags() {
+		if t == tag {
+			return true
+		}
+	}
+	return false
 }
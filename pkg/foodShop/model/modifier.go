@@ -0,0 +1,77 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+)
+
+// ModifierOption is one choice in a modifier group, e.g. "Large" for
+// +15 THB. PriceDelta is added to the unit price and may be negative.
+type ModifierOption struct {
+	Code       string       `json:"code"`
+	Name       string       `json:"name"`
+	PriceDelta domain.Money `json:"priceDelta,omitempty"`
+}
+
+// ModifierGroup is a set of options a customer picks from for one menu
+// item, e.g. size or extras. A customer picks between MinSelections and
+// MaxSelections options from the group; a MaxSelections of zero means no
+// upper limit.
+type ModifierGroup struct {
+	Code          string           `json:"code"`
+	Name          string           `json:"name"`
+	MinSelections int              `json:"minSelections,omitempty"`
+	MaxSelections int              `json:"maxSelections,omitempty"`
+	Options       []ModifierOption `json:"options"`
+}
+
+// Required reports whether at least one option must be picked.
+func (g ModifierGroup) Required() bool {
+	return g.MinSelections > 0
+}
+
+// Rule reads the group's limits for the menu, e.g. "required, pick 1" or
+// "optional, up to 3".
+func (g ModifierGroup) Rule() string {
+	need := "optional"
+	if g.Required() {
+		need = "required"
+	}
+
+	switch {
+	case g.MaxSelections == 0 && g.MinSelections == 0:
+		return need + ", any number"
+	case g.MaxSelections == 0:
+		return fmt.Sprintf("%s, at least %d", need, g.MinSelections)
+	case g.MinSelections == g.MaxSelections:
+		return fmt.Sprintf("%s, pick %d", need, g.MaxSelections)
+	default:
+		return fmt.Sprintf("%s, up to %d", need, g.MaxSelections)
+	}
+}
+
+func (g ModifierGroup) Clone() ModifierGroup {
+	g.Options = append([]ModifierOption(nil), g.Options...)
+	return g
+}
+
+// SelectedOption is an option chosen on an order line, with the group it
+// came from.
+type SelectedOption struct {
+	Group      string
+	Code       string
+	Name       string
+	PriceDelta domain.Money
+}
+
+// OptionNames joins the names of the selected options, e.g.
+// "Large, Extra egg".
+func OptionNames(options []SelectedOption) string {
+	names := make([]string, 0, len(options))
+	for _, option := range options {
+		names = append(names, option.Name)
+	}
+	return strings.Join(names, ", ")
+}
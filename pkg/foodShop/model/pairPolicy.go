@@ -1,9 +1,11 @@
 package model
 
+import "github.com/TewApirat/food-shop/pkg/foodShop/domain"
+
 type PairDiscountPolicy struct {
-	EligibleCodes map[MenuItemCode]bool 
-	DiscountPercent int64                
-	BundleSize int                    
+	EligibleCodes map[MenuItemCode]bool
+	DiscountRate  domain.Rate
+	BundleSize    int
 }
 
 func DefaultPairDiscountPolicy() PairDiscountPolicy {
@@ -13,7 +15,7 @@ func DefaultPairDiscountPolicy() PairDiscountPolicy {
 			"PINK":   true,
 			"GREEN":  true,
 		},
-		DiscountPercent: 5,
-		BundleSize:      2,
+		DiscountRate: domain.PercentRate(5),
+		BundleSize:   2,
 	}
 }
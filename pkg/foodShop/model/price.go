@@ -0,0 +1,48 @@
+package model
+
+import (
+	"time"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+)
+
+// PriceVersion is one price of a menu item and the moment it takes effect.
+// Version 1 is the item's opening Price, in effect from the zero time;
+// each price change adds the next version.
+type PriceVersion struct {
+	Version       int          `json:"version"`
+	Price         domain.Money `json:"price"`
+	EffectiveFrom time.Time    `json:"effectiveFrom,omitzero"`
+}
+
+// Prices lists every price version of the item, oldest first.
+func (m MenuItem) Prices() []PriceVersion {
+	versions := make([]PriceVersion, 0, len(m.PriceChanges)+1)
+	versions = append(versions, PriceVersion{Version: 1, Price: m.Price})
+	return append(versions, m.PriceChanges...)
+}
+
+// PriceAt returns the price version in effect at t: the latest one that
+// took effect at or before it.
+func (m MenuItem) PriceAt(t time.Time) PriceVersion {
+	versions := m.Prices()
+	current := versions[0]
+	for _, version := range versions[1:] {
+		if version.EffectiveFrom.After(t) {
+			break
+		}
+		current = version
+	}
+	return current
+}
+
+// NextPriceAfter returns the first price change that takes effect after
+// t, and false when none is scheduled.
+func (m MenuItem) NextPriceAfter(t time.Time) (PriceVersion, bool) {
+	for _, version := range m.PriceChanges {
+		if version.EffectiveFrom.After(t) {
+			return version, true
+		}
+	}
+	return PriceVersion{}, false
+}
@@ -1,7 +1,94 @@
 package model
 
+import (
+	"time"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+)
+
+type PromotionType string
+
+const (
+	PromotionTypePair   PromotionType = "PAIR"
+	PromotionTypeMember PromotionType = "MEMBER"
+	// PromotionTypeBuyXGetY: "buy 3 BLUE get 1 free", or with rewardCodes
+	// "buy 2 ORANGE get a RED free".
+	PromotionTypeBuyXGetY PromotionType = "BUY_X_GET_Y"
+	// PromotionTypeNthFree: "every 3rd YELLOW free".
+	PromotionTypeNthFree PromotionType = "NTH_FREE"
+	// PromotionTypeCombo: "any RED + BLUE for 70 THB". All combos are
+	// settled together so the cart is split the way that saves the most.
+	PromotionTypeCombo PromotionType = "COMBO"
+	// PromotionTypeSpendTier: "spend 500 THB get 30 THB off".
+	PromotionTypeSpendTier PromotionType = "SPEND_TIER"
+)
+
+// Promotion is both the display text of a promotion and the configuration
+// its pricing rule is built from, so the two can never drift apart.
+// Promotions run in ascending Priority order; a nil Schedule means the
+// promotion is always on. Stacking limits which promotions apply together.
 type Promotion struct {
-	Code        string
-	Title       string
-	Description string
+	Code          string            `json:"code"`
+	Type          PromotionType     `json:"type"`
+	Title         string            `json:"title"`
+	Description   string            `json:"description"`
+	Priority      int               `json:"priority"`
+	EligibleCodes []MenuItemCode    `json:"eligibleCodes,omitempty"`
+	Params        PromotionParams   `json:"params"`
+	Schedule      *Schedule         `json:"schedule,omitempty"`
+	Stacking      PromotionStacking `json:"stacking,omitzero"`
+}
+
+func (p Promotion) ActiveAt(t time.Time) (bool, error) {
+	if p.Schedule == nil {
+		return true, nil
+	}
+	return p.Schedule.ActiveAt(t)
+}
+
+// Clone returns a copy that shares no slices, maps or pointers with p.
+func (p Promotion) Clone() Promotion {
+	p.EligibleCodes = append([]MenuItemCode(nil), p.EligibleCodes...)
+	p.Params.RewardCodes = append([]MenuItemCode(nil), p.Params.RewardCodes...)
+	p.Params.Tiers = append([]SpendTierParams(nil), p.Params.Tiers...)
+	p.Stacking.CannotCombineWith = append([]string(nil), p.Stacking.CannotCombineWith...)
+	if p.Params.ComboItems != nil {
+		comboItems := make(map[MenuItemCode]int, len(p.Params.ComboItems))
+		for code, qty := range p.Params.ComboItems {
+			comboItems[code] = qty
+		}
+		p.Params.ComboItems = comboItems
+	}
+	if p.Schedule != nil {
+		schedule := *p.Schedule
+		schedule.Days = append([]string(nil), schedule.Days...)
+		p.Schedule = &schedule
+	}
+	return p
+}
+
+// PromotionStatus is a promotion as listed to customers at a given moment.
+type PromotionStatus struct {
+	Promotion
+	Active bool
+}
+
+type PromotionParams struct {
+	DiscountRate  domain.Rate          `json:"discountPercent,omitempty"`
+	BundleSize    int                  `json:"bundleSize,omitempty"`
+	BuyQty        int                  `json:"buyQty,omitempty"`
+	FreeQty       int                  `json:"freeQty,omitempty"`
+	Nth           int                  `json:"nth,omitempty"`
+	RewardCodes   []MenuItemCode       `json:"rewardCodes,omitempty"`
+	AutoAddReward bool                 `json:"autoAddReward,omitempty"`
+	ComboItems    map[MenuItemCode]int `json:"comboItems,omitempty"`
+	ComboPrice    domain.Money         `json:"comboPrice,omitempty"`
+	SpendBase     SpendBase            `json:"base,omitempty"`
+	Tiers         []SpendTierParams    `json:"tiers,omitempty"`
+}
+
+type SpendTierParams struct {
+	MinSpend     domain.Money `json:"minSpend"`
+	AmountOff    domain.Money `json:"amountOff,omitempty"`
+	DiscountRate domain.Rate  `json:"discountPercent,omitempty"`
 }
@@ -0,0 +1,26 @@
+package model
+
+import "github.com/TewApirat/food-shop/pkg/foodShop/domain"
+
+// PromotionStacking says which promotions may be applied together. At most
+// one promotion of an ExclusiveGroup applies to an order, CannotCombineWith
+// works both ways, and MaxDiscount caps what the promotion can take
+// off (0 means no cap).
+type PromotionStacking struct {
+	ExclusiveGroup    string       `json:"exclusiveGroup,omitempty"`
+	CannotCombineWith []string     `json:"cannotCombineWith,omitempty"`
+	MaxDiscount       domain.Money `json:"maxDiscount,omitempty"`
+}
+
+// Constrained reports whether the stacking policy can keep p out of an
+// order, i.e. whether p has to be chosen against other promotions.
+func (s PromotionStacking) Constrained() bool {
+	return s.ExclusiveGroup != "" || len(s.CannotCombineWith) > 0
+}
+
+// PromotionCombination is one set of promotions that may be stacked,
+// listed in the order they apply, with the total left after them.
+type PromotionCombination struct {
+	Codes []string
+	Total domain.Money
+}
@@ -1,25 +1,135 @@
 package model
 
-import "github.com/TewApirat/food-shop/pkg/foodShop/domain"
+import (
+	"time"
 
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_memberModel "github.com/TewApirat/food-shop/pkg/member/model"
+)
 
 type PurchasingRequest struct {
-	Items  map[string]int `json:"items"`
-	Member bool           `json:"member"`
+	// Items orders menu items without options, by code and quantity.
+	Items map[string]int `json:"items"`
+	// Lines orders menu items with their chosen options; both Items and
+	// Lines may be used on the same request.
+	Lines []RequestLine `json:"lines,omitempty"`
+	// MemberID is a member ID or card number; empty for walk-in customers.
+	MemberID   string   `json:"memberId,omitempty"`
+	CustomerID string   `json:"customerId,omitempty"`
+	Coupons    []string `json:"coupons,omitempty"`
+	// DisplayCurrency asks for the total converted into another currency,
+	// e.g. "USD". Settlement stays in THB.
+	DisplayCurrency string `json:"displayCurrency,omitempty"`
+}
+
+// RequestLine is one ordered item with the option codes chosen for it,
+// e.g. {"code":"RED","qty":1,"options":["LARGE","EXTRA_EGG","NO_CHILI"]}.
+type RequestLine struct {
+	Code    string   `json:"code"`
+	Qty     int      `json:"qty"`
+	Options []string `json:"options,omitempty"`
 }
 
 type OrderLine struct {
-	Code      MenuItemCode
-	Name      string
-	Qty       int
+	Code    MenuItemCode
+	Name    string
+	Qty     int
+	FreeQty int
+	// Options are the modifiers chosen for the line. MenuPrice is the menu
+	// price version in effect when the order was quoted; UnitPrice is its
+	// price plus the options' price deltas.
+	Options   []SelectedOption
+	MenuPrice PriceVersion
 	UnitPrice domain.Money
 	LineTotal domain.Money
+	// Discounts is this line's share of each promotion and coupon, in the
+	// order they applied; NetTotal is LineTotal less all of them.
+	Discounts []LineDiscount
+	NetTotal  domain.Money
+}
+
+// LineDiscount is the part of a promotion or coupon, by code, that falls
+// on one order line.
+type LineDiscount struct {
+	Code   string
+	Amount domain.Money
+}
+
+// AppliedPromotion explains one promotion on a quote: the units it covered,
+// the units of those it gave away, the amount its rate or price was applied
+// to and what it took off. Rate is zero for promotions that are not a
+// percentage, e.g. free items.
+type AppliedPromotion struct {
+	Code        string
+	Items       map[MenuItemCode]int
+	FreeUnits   map[MenuItemCode]int
+	Base        domain.Money
+	Rate        domain.Rate
+	Discount    domain.Money
+	Explanation string
+}
+
+// AppliedCoupon is a coupon taken off a quote. EligibleCodes are the items
+// it was limited to, empty when it applied to the whole bill.
+type AppliedCoupon struct {
+	Code          string
+	Discount      domain.Money
+	EligibleCodes []MenuItemCode
+}
+
+// DisplayTotal is a quote total converted for showing only: the customer
+// still pays the THB total. Rate is the THB price of one unit of the
+// display currency, as published at RateUpdatedAt.
+type DisplayTotal struct {
+	Total         domain.Amount
+	Rate          domain.ExchangeRate
+	RateUpdatedAt time.Time
 }
 
 type OrderQuote struct {
-	Lines          []OrderLine
+	Lines []OrderLine
+	// MemberID is the resolved member ID, even when the request used a card
+	// number.
+	MemberID       string
+	MemberTier     _memberModel.MemberTier
 	Subtotal       domain.Money
 	PairDiscount   domain.Money
 	MemberDiscount domain.Money
-	Total          domain.Money
+	// PromotionDiscount sums every promotion that is neither the pair nor
+	// the member discount, e.g. free items.
+	PromotionDiscount domain.Money
+	CouponDiscount    domain.Money
+	Coupons           []AppliedCoupon
+	Total             domain.Money
+	// GrandTotal is Total with the service charge and VAT of the Tax policy
+	// added; PreTax + ServiceCharge + VAT is always GrandTotal. Without
+	// taxes GrandTotal equals Total.
+	PreTax        domain.Money
+	ServiceCharge domain.Money
+	VAT           domain.Money
+	GrandTotal    domain.Money
+	Tax           TaxPolicy
+	// CashTotal is GrandTotal rounded for a cash payment and CashRounding
+	// the difference (negative when rounded down). Rounding is the strategy
+	// the quote was priced with.
+	CashRounding domain.Money
+	CashTotal    domain.Money
+	Rounding     RoundingStrategy
+	// Display is GrandTotal in the requested display currency, nil when none
+	// was requested.
+	Display *DisplayTotal
+	// Stock is what is left of the stock-tracked items on the order once
+	// it was reserved; unlimited items are not listed.
+	Stock []StockLevel
+	// NextTiers tells the customer what spending a little more would unlock.
+	NextTiers []SpendTierHint
+	// AppliedPromotions explains every promotion that took something off,
+	// in the order they applied.
+	AppliedPromotions []AppliedPromotion
+	// ChosenCombination is the combination of promotions the quote was
+	// priced with, in the order they applied. RejectedCombinations are the
+	// other combinations the stacking policy allowed; none of them beat
+	// the chosen one.
+	ChosenCombination    []string
+	RejectedCombinations []PromotionCombination
 }
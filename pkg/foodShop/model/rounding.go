@@ -0,0 +1,64 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+)
+
+// RoundingStrategy says how each pricing step rounds to satang. It is
+// recorded on every quote so that a historical total can be recomputed the
+// same way. Promotion covers item and spend-tier percentages; Allocation
+// covers the per-line shares of each discount and Tax the service charge
+// and VAT. With a CashUnit the amount payable in cash is the grand total
+// rounded to that unit with Cash.
+type RoundingStrategy struct {
+	Promotion  domain.RoundingMode `json:"promotion"`
+	Member     domain.RoundingMode `json:"member"`
+	Coupon     domain.RoundingMode `json:"coupon"`
+	Allocation domain.RoundingMode `json:"allocation"`
+	Tax        domain.RoundingMode `json:"tax"`
+	Cash       domain.RoundingMode `json:"cash"`
+	CashUnit   domain.Money        `json:"cashUnit"`
+}
+
+// DefaultRoundingStrategy floors every discount, which is how quotes have
+// always been priced, rounds tax half-even (banker's rounding) and does
+// no cash rounding.
+func DefaultRoundingStrategy() RoundingStrategy {
+	return RoundingStrategy{
+		Promotion:  domain.RoundFloor,
+		Member:     domain.RoundFloor,
+		Coupon:     domain.RoundFloor,
+		Allocation: domain.RoundFloor,
+		Tax:        domain.RoundHalfEven,
+		Cash:       domain.RoundHalfUp,
+	}
+}
+
+// AccountingRoundingStrategy rounds discounts half-up, tax half-even
+// (banker's rounding) and cash payments to the nearest 0.25 THB.
+func AccountingRoundingStrategy() RoundingStrategy {
+	return RoundingStrategy{
+		Promotion:  domain.RoundHalfUp,
+		Member:     domain.RoundHalfUp,
+		Coupon:     domain.RoundHalfUp,
+		Allocation: domain.RoundHalfUp,
+		Tax:        domain.RoundHalfEven,
+		Cash:       domain.RoundHalfUp,
+		CashUnit:   domain.Money(25),
+	}
+}
+
+// RoundingStrategyByName returns the named built-in strategy: "default"
+// or "accounting".
+func RoundingStrategyByName(name string) (RoundingStrategy, error) {
+	switch name {
+	case "", "default":
+		return DefaultRoundingStrategy(), nil
+	case "accounting":
+		return AccountingRoundingStrategy(), nil
+	default:
+		return RoundingStrategy{}, fmt.Errorf("unknown rounding strategy %q", name)
+	}
+}
@@ -0,0 +1,200 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	// Schedules default to Asia/Bangkok; embed the zone database so they
+	// still resolve on hosts without one (e.g. the alpine image).
+	_ "time/tzdata"
+)
+
+const DefaultTimezone = "Asia/Bangkok"
+
+const (
+	scheduleDateLayout = "2006-01-02"
+	scheduleTimeLayout = "15:04"
+)
+
+var scheduleWeekdays = map[string]time.Weekday{
+	"SUN": time.Sunday,
+	"MON": time.Monday,
+	"TUE": time.Tuesday,
+	"WED": time.Wednesday,
+	"THU": time.Thursday,
+	"FRI": time.Friday,
+	"SAT": time.Saturday,
+}
+
+// Schedule is a validity window evaluated in Timezone. Every part is
+// optional: dates are inclusive, Days uses MON..SUN, and TimeFrom/TimeTo
+// ("HH:MM", end exclusive) may wrap past midnight.
+type Schedule struct {
+	StartDate string   `json:"startDate,omitempty"`
+	EndDate   string   `json:"endDate,omitempty"`
+	Days      []string `json:"days,omitempty"`
+	TimeFrom  string   `json:"timeFrom,omitempty"`
+	TimeTo    string   `json:"timeTo,omitempty"`
+	Timezone  string   `json:"timezone,omitempty"`
+}
+
+func (s Schedule) Validate() error {
+	if _, err := s.location(); err != nil {
+		return err
+	}
+	for _, date := range []string{s.StartDate, s.EndDate} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse(scheduleDateLayout, date); err != nil {
+			return fmt.Errorf("invalid date %q (want YYYY-MM-DD)", date)
+		}
+	}
+	if s.StartDate != "" && s.EndDate != "" && s.EndDate < s.StartDate {
+		return fmt.Errorf("endDate %s is before startDate %s", s.EndDate, s.StartDate)
+	}
+	for _, day := range s.Days {
+		if _, ok := scheduleWeekdays[strings.ToUpper(day)]; !ok {
+			return fmt.Errorf("invalid day %q (want MON..SUN)", day)
+		}
+	}
+	if (s.TimeFrom == "") != (s.TimeTo == "") {
+		return fmt.Errorf("timeFrom and timeTo must be set together")
+	}
+	for _, clock := range []string{s.TimeFrom, s.TimeTo} {
+		if clock == "" {
+			continue
+		}
+		if _, err := time.Parse(scheduleTimeLayout, clock); err != nil {
+			return fmt.Errorf("invalid time %q (want HH:MM)", clock)
+		}
+	}
+	if s.TimeFrom != "" && minuteOfDay(s.TimeFrom) == minuteOfDay(s.TimeTo) {
+		return fmt.Errorf("timeFrom and timeTo are both %s, so the window is never open", s.TimeFrom)
+	}
+	return nil
+}
+
+func (s Schedule) ActiveAt(t time.Time) (bool, error) {
+	if err := s.Validate(); err != nil {
+		return false, err
+	}
+	loc, _ := s.location()
+	local := t.In(loc)
+
+	date := local.Format(scheduleDateLayout)
+	if s.StartDate != "" && date < s.StartDate {
+		return false, nil
+	}
+	if s.EndDate != "" && date > s.EndDate {
+		return false, nil
+	}
+
+	if len(s.Days) > 0 {
+		onDay := false
+		for _, day := range s.Days {
+			if scheduleWeekdays[strings.ToUpper(day)] == local.Weekday() {
+				onDay = true
+				break
+			}
+		}
+		if !onDay {
+			return false, nil
+		}
+	}
+
+	if s.TimeFrom != "" {
+		from, to := minuteOfDay(s.TimeFrom), minuteOfDay(s.TimeTo)
+		now := local.Hour()*60 + local.Minute()
+		if from <= to {
+			return now >= from && now < to, nil
+		}
+		return now >= from || now < to, nil
+	}
+
+	return true, nil
+}
+
+// String renders the window for display, e.g. "MON,TUE 14:00-17:00 (Asia/Bangkok)".
+func (s Schedule) String() string {
+	parts := make([]string, 0, 4)
+	switch {
+	case s.StartDate != "" && s.EndDate != "":
+		parts = append(parts, s.StartDate+" to "+s.EndDate)
+	case s.StartDate != "":
+		parts = append(parts, "from "+s.StartDate)
+	case s.EndDate != "":
+		parts = append(parts, "until "+s.EndDate)
+	}
+	if len(s.Days) > 0 {
+		parts = append(parts, strings.ToUpper(strings.Join(s.Days, ",")))
+	}
+	if s.TimeFrom != "" {
+		parts = append(parts, s.TimeFrom+"-"+s.TimeTo)
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "always")
+	}
+	return fmt.Sprintf("%s (%s)", strings.Join(parts, " "), s.timezone())
+}
+
+func (s Schedule) timezone() string {
+	if s.Timezone == "" {
+		return DefaultTimezone
+	}
+	return s.Timezone
+}
+
+func (s Schedule) location() (*time.Location, error) {
+	loc, err := time.LoadLocation(s.timezone())
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q", s.timezone())
+	}
+	return loc, nil
+}
+
+func minuteOfDay(clock string) int {
+	t, _ := time.Parse(scheduleTimeLayout, clock)
+	return t.Hour()*60 + t.Minute()
+}
+
+// NextActiveAfter returns the first moment after t at which the schedule
+// is active; when it is not active at t, that is when it next opens. It
+// reports false when the schedule has ended for good.
+func (s Schedule) NextActiveAfter(t time.Time) (time.Time, bool, error) {
+	if err := s.Validate(); err != nil {
+		return time.Time{}, false, err
+	}
+	loc, _ := s.location()
+	local := t.In(loc)
+
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	if s.StartDate != "" {
+		start, _ := time.ParseInLocation(scheduleDateLayout, s.StartDate, loc)
+		if start.After(day) {
+			day = start
+		}
+	}
+
+	// A window can only open at midnight (a new date or weekday) or at
+	// TimeFrom, and every weekday comes round within a week of the first
+	// date it may open on.
+	for i := 0; i <= 7; i++ {
+		d := day.AddDate(0, 0, i)
+		candidates := []time.Time{d}
+		if s.TimeFrom != "" {
+			from := minuteOfDay(s.TimeFrom)
+			candidates = append(candidates, time.Date(d.Year(), d.Month(), d.Day(), from/60, from%60, 0, 0, loc))
+		}
+		for _, candidate := range candidates {
+			if !candidate.After(t) {
+				continue
+			}
+			if active, _ := s.ActiveAt(candidate); active {
+				return candidate, true, nil
+			}
+		}
+	}
+	return time.Time{}, false, nil
+}
@@ -0,0 +1,40 @@
+package model
+
+import "github.com/TewApirat/food-shop/pkg/foodShop/domain"
+
+// SpendBase is the amount a spend-tier promotion measures the bill by.
+// AFTER_PAIR deducts the pair discount and every other item-level
+// promotion (free items, combos); AFTER_MEMBER also deducts the member
+// discount.
+type SpendBase string
+
+const (
+	SpendBaseSubtotal    SpendBase = "SUBTOTAL"
+	SpendBaseAfterPair   SpendBase = "AFTER_PAIR"
+	SpendBaseAfterMember SpendBase = "AFTER_MEMBER"
+)
+
+// SpendTierPolicy gives the reward of the highest tier whose MinSpend the
+// base reaches. Each tier takes either AmountOff or Rate off the base.
+type SpendTierPolicy struct {
+	Base  SpendBase
+	Tiers []SpendTier
+}
+
+type SpendTier struct {
+	MinSpend  domain.Money
+	AmountOff domain.Money
+	Rate      domain.Rate
+}
+
+// SpendTierHint tells the customer how much more to spend to reach the
+// next tier of a promotion.
+type SpendTierHint struct {
+	PromotionCode string
+	AmountToGo    domain.Money
+	Reward        string
+}
+
+func (h SpendTierHint) Message() string {
+	return "add " + h.AmountToGo.String() + " more to unlock " + h.Reward
+}
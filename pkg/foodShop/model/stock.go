@@ -0,0 +1,13 @@
+package model
+
+// StockLevel is how many units of a stock-tracked menu item are left to
+// sell. Menu items without a stock level are unlimited.
+type StockLevel struct {
+	Code      MenuItemCode
+	Remaining int
+}
+
+// SoldOut reports whether nothing is left.
+func (l StockLevel) SoldOut() bool {
+	return l.Remaining <= 0
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+)
+
+// MenuPricing says whether menu prices already contain VAT.
+type MenuPricing string
+
+const (
+	MenuPricingVATExclusive MenuPricing = "VAT_EXCLUSIVE"
+	MenuPricingVATInclusive MenuPricing = "VAT_INCLUSIVE"
+)
+
+// TaxBase is the amount the service charge and VAT are worked out on.
+// AFTER_DISCOUNTS charges on what is left after promotions and coupons;
+// BEFORE_DISCOUNTS charges on the full subtotal and takes the discounts
+// off afterwards.
+type TaxBase string
+
+const (
+	TaxBaseAfterDiscounts  TaxBase = "AFTER_DISCOUNTS"
+	TaxBaseBeforeDiscounts TaxBase = "BEFORE_DISCOUNTS"
+)
+
+// TaxPolicy is the service charge and VAT added to a quote. The service
+// charge is worked out on the amount before VAT and VAT on the amount plus
+// the service charge. The zero value charges nothing, with VAT-exclusive
+// prices and charges after discounts.
+type TaxPolicy struct {
+	ServiceChargeRate domain.Rate `json:"serviceChargeRate"`
+	VATRate           domain.Rate `json:"vatRate"`
+	Pricing           MenuPricing `json:"pricing"`
+	Base              TaxBase     `json:"base"`
+}
+
+// Charges reports whether the policy adds anything to a quote.
+func (p TaxPolicy) Charges() bool {
+	return p.ServiceChargeRate > 0 || p.VATRate > 0
+}
+
+// ThaiTaxPolicy is the usual Thai restaurant bill: 10% service charge,
+// then 7% VAT, both on the bill after discounts. pricing says whether the
+// menu prices already include the VAT.
+func ThaiTaxPolicy(pricing MenuPricing) TaxPolicy {
+	return TaxPolicy{
+		ServiceChargeRate: domain.PercentRate(10),
+		VATRate:           domain.PercentRate(7),
+		Pricing:           pricing,
+		Base:              TaxBaseAfterDiscounts,
+	}
+}
+
+// TaxPolicyByName returns the named built-in policy: "none",
+// "thai-exclusive" or "thai-inclusive".
+func TaxPolicyByName(name string) (TaxPolicy, error) {
+	switch name {
+	case "", "none":
+		return TaxPolicy{}, nil
+	case "thai-exclusive":
+		return ThaiTaxPolicy(MenuPricingVATExclusive), nil
+	case "thai-inclusive":
+		return ThaiTaxPolicy(MenuPricingVATInclusive), nil
+	default:
+		return TaxPolicy{}, fmt.Errorf("unknown tax policy %q", name)
+	}
+}
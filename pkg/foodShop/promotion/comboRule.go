@@ -0,0 +1,180 @@
+package promotion
+
+import (
+	"fmt"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	"github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	"github.com/TewApirat/food-shop/pkg/foodShop/model"
+)
+
+// comboSearchBudget caps how many partial splits the combo search visits
+// before it stops branching and takes every remaining combo greedily.
+const comboSearchBudget = 200000
+
+type Combo struct {
+	Code   string
+	Policy model.ComboPolicy
+}
+
+// comboGroupRule settles every combo promotion at once so that, when the
+// cart can be split into combos in more than one way, the split that saves
+// the customer the most wins.
+type comboGroupRule struct {
+	combos []Combo
+}
+
+func NewComboGroupRule(combos ...Combo) PromotionRule {
+	ownedCombos := make([]Combo, len(combos))
+	copy(ownedCombos, combos)
+	return &comboGroupRule{combos: ownedCombos}
+}
+
+func (r *comboGroupRule) Code() string { return "COMBO" }
+func (r *comboGroupRule) Kind() Kind   { return KindPromotion }
+
+func (r *comboGroupRule) Evaluate(ctx Context) (Outcome, error) {
+	results, err := r.EvaluateGroup(ctx)
+	if err != nil {
+		return Outcome{}, err
+	}
+
+	var outcome Outcome
+	for _, result := range results {
+		outcome.Discount = outcome.Discount.Add(result.Discount)
+		outcome.ClaimedUnits = mergeUnits(outcome.ClaimedUnits, result.ClaimedUnits)
+	}
+	return outcome, nil
+}
+
+func (r *comboGroupRule) EvaluateGroup(ctx Context) ([]Result, error) {
+	savings := make([]domain.Money, len(r.combos))
+	for i, combo := range r.combos {
+		saving, err := comboSaving(ctx, combo.Policy)
+		if err != nil {
+			return nil, err
+		}
+		savings[i] = saving
+	}
+
+	counts := r.bestSplit(ctx, savings)
+
+	results := make([]Result, 0, len(r.combos))
+	for i, combo := range r.combos {
+		claimed := make(map[model.MenuItemCode]int, len(combo.Policy.Items))
+		for code, qty := range combo.Policy.Items {
+			if counts[i] > 0 {
+				claimed[code] = qty * counts[i]
+			}
+		}
+		regular := combo.Policy.Price.Add(savings[i])
+		results = append(results, Result{
+			Code: combo.Code,
+			Kind: KindPromotion,
+			Outcome: Outcome{
+				Discount:     savings[i].MulInt(counts[i]),
+				ClaimedUnits: claimed,
+				Items:        claimed,
+				Base:         regular.MulInt(counts[i]),
+				Explanation: fmt.Sprintf("%d x combo at %s instead of %s: %s",
+					counts[i], combo.Policy.Price, regular, unitsLabel(claimed)),
+			},
+		})
+	}
+	return results, nil
+}
+
+// comboSaving is what one combo saves over buying its items separately, or
+// zero when the cart cannot fill it or it is not actually cheaper.
+func comboSaving(ctx Context, policy model.ComboPolicy) (domain.Money, error) {
+	if len(policy.Items) == 0 {
+		return domain.Money(0), nil
+	}
+
+	var value domain.Money
+	for code, qty := range policy.Items {
+		if qty < 1 || ctx.Available(code) < qty {
+			return domain.Money(0), nil
+		}
+		price, ok := ctx.PriceByCode[code]
+		if !ok {
+			return domain.Money(0), &exception.MenuItemPriceMissingError{Code: code}
+		}
+		value = value.Add(price.MulInt(qty))
+	}
+
+	if value <= policy.Price {
+		return domain.Money(0), nil
+	}
+	return value.Sub(policy.Price), nil
+}
+
+// bestSplit searches how many of each combo to use, most first, pruning any
+// branch that cannot beat the best split found so far. Earlier combos win
+// ties because a later split must save strictly more to replace one.
+func (r *comboGroupRule) bestSplit(ctx Context, savings []domain.Money) []int {
+	available := make(map[model.MenuItemCode]int, len(ctx.QtyByCode))
+	for code := range ctx.QtyByCode {
+		available[code] = ctx.Available(code)
+	}
+
+	current := make([]int, len(r.combos))
+	best := make([]int, len(r.combos))
+	var bestSaving domain.Money
+	budget := comboSearchBudget
+
+	maxCount := func(i int) int {
+		if savings[i] <= 0 {
+			return 0
+		}
+		count := -1
+		for code, qty := range r.combos[i].Policy.Items {
+			if n := available[code] / qty; count < 0 || n < count {
+				count = n
+			}
+		}
+		return max(count, 0)
+	}
+
+	var search func(i int, saving domain.Money)
+	search = func(i int, saving domain.Money) {
+		budget--
+		if i == len(r.combos) {
+			if saving > bestSaving {
+				bestSaving = saving
+				copy(best, current)
+			}
+			return
+		}
+
+		bound := saving
+		for j := i; j < len(r.combos); j++ {
+			bound = bound.Add(savings[j].MulInt(maxCount(j)))
+		}
+		if bound <= bestSaving {
+			return
+		}
+
+		n := maxCount(i)
+		lowest := 0
+		if budget <= 0 {
+			lowest = n
+		}
+		for k := n; k >= lowest; k-- {
+			for code, qty := range r.combos[i].Policy.Items {
+				available[code] -= qty * k
+			}
+			current[i] = k
+
+			search(i+1, saving.Add(savings[i].MulInt(k)))
+
+			for code, qty := range r.combos[i].Policy.Items {
+				available[code] += qty * k
+			}
+		}
+		current[i] = 0
+	}
+
+	search(0, domain.Money(0))
+	return best
+}
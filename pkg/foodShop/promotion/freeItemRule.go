@@ -0,0 +1,170 @@
+package promotion
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	"github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	"github.com/TewApirat/food-shop/pkg/foodShop/model"
+)
+
+type freeItemRule struct {
+	code   string
+	policy model.FreeItemPolicy
+}
+
+func NewFreeItemRule(code string, policy model.FreeItemPolicy) PromotionRule {
+	return &freeItemRule{
+		code:   code,
+		policy: policy,
+	}
+}
+
+func (r *freeItemRule) Code() string { return r.code }
+func (r *freeItemRule) Kind() Kind   { return KindPromotion }
+
+// Evaluate frees the cheapest qualifying units and claims them together
+// with the most expensive units that paid for them.
+func (r *freeItemRule) Evaluate(ctx Context) (Outcome, error) {
+	if r.policy.BuyQty < 1 || r.policy.FreeQty < 1 {
+		return Outcome{}, nil
+	}
+
+	eligibleQty := countAvailable(ctx, r.policy.EligibleCodes)
+
+	if len(r.policy.RewardCodes) == 0 {
+		groups := eligibleQty / (r.policy.BuyQty + r.policy.FreeQty)
+		if groups == 0 {
+			return Outcome{}, nil
+		}
+
+		free, discount, err := pickUnits(ctx, r.policy.EligibleCodes, groups*r.policy.FreeQty, true, nil)
+		if err != nil {
+			return Outcome{}, err
+		}
+		paid, _, err := pickUnits(ctx, r.policy.EligibleCodes, groups*r.policy.BuyQty, false, free)
+		if err != nil {
+			return Outcome{}, err
+		}
+		return r.outcome(free, paid, discount), nil
+	}
+
+	// When the reward codes overlap the eligible ones a unit can be free
+	// or pay for a free unit, never both, so shrink the number of sets
+	// until the units left after the free ones still pay for them.
+	for sets := eligibleQty / r.policy.BuyQty; sets > 0; sets-- {
+		free, discount, err := pickUnits(ctx, r.policy.RewardCodes, sets*r.policy.FreeQty, true, nil)
+		if err != nil {
+			return Outcome{}, err
+		}
+
+		freeCount, freeEligible := 0, 0
+		for code, qty := range free {
+			freeCount += qty
+			if r.policy.EligibleCodes[code] {
+				freeEligible += qty
+			}
+		}
+		usedSets := (freeCount + r.policy.FreeQty - 1) / r.policy.FreeQty
+		if (eligibleQty-freeEligible)/r.policy.BuyQty < usedSets {
+			continue
+		}
+
+		paid, _, err := pickUnits(ctx, r.policy.EligibleCodes, usedSets*r.policy.BuyQty, false, free)
+		if err != nil {
+			return Outcome{}, err
+		}
+		return r.outcome(free, paid, discount), nil
+	}
+	return Outcome{}, nil
+}
+
+func (r *freeItemRule) outcome(free, paid map[model.MenuItemCode]int, value domain.Money) Outcome {
+	claimed := mergeUnits(free, paid)
+	return Outcome{
+		Discount:     value,
+		FreeUnits:    free,
+		ClaimedUnits: claimed,
+		Items:        claimed,
+		Base:         value,
+		Explanation: fmt.Sprintf("buy %d get %d free: %s free (%s)",
+			r.policy.BuyQty, r.policy.FreeQty, unitsLabel(free), value),
+	}
+}
+
+func (r *freeItemRule) RewardItems(ctx Context) map[model.MenuItemCode]int {
+	if r.policy.AutoAddReward == "" || r.policy.BuyQty < 1 || r.policy.FreeQty < 1 {
+		return nil
+	}
+
+	sets := countAvailable(ctx, r.policy.EligibleCodes) / r.policy.BuyQty
+	if sets == 0 {
+		return nil
+	}
+	return map[model.MenuItemCode]int{r.policy.AutoAddReward: sets * r.policy.FreeQty}
+}
+
+func countAvailable(ctx Context, codes map[model.MenuItemCode]bool) int {
+	total := 0
+	for code := range ctx.QtyByCode {
+		if codes[code] {
+			total += ctx.Available(code)
+		}
+	}
+	return total
+}
+
+// pickUnits takes up to count available units from pool, cheapest or most
+// expensive first, skipping units already in exclude. Ties are broken by
+// code so the result does not depend on map order.
+func pickUnits(
+	ctx Context,
+	pool map[model.MenuItemCode]bool,
+	count int,
+	cheapestFirst bool,
+	exclude map[model.MenuItemCode]int,
+) (map[model.MenuItemCode]int, domain.Money, error) {
+	codes := make([]model.MenuItemCode, 0, len(pool))
+	for code := range pool {
+		if ctx.Available(code)-exclude[code] < 1 {
+			continue
+		}
+		if _, ok := ctx.PriceByCode[code]; !ok {
+			return nil, domain.Money(0), &exception.MenuItemPriceMissingError{Code: code}
+		}
+		codes = append(codes, code)
+	}
+
+	sort.Slice(codes, func(i, j int) bool {
+		pi, pj := ctx.PriceByCode[codes[i]], ctx.PriceByCode[codes[j]]
+		if pi != pj {
+			return (pi < pj) == cheapestFirst
+		}
+		return codes[i] < codes[j]
+	})
+
+	picked := make(map[model.MenuItemCode]int)
+	var value domain.Money
+	for _, code := range codes {
+		if count == 0 {
+			break
+		}
+		take := min(count, ctx.Available(code)-exclude[code])
+		picked[code] = take
+		value = value.Add(ctx.PriceByCode[code].MulInt(take))
+		count -= take
+	}
+	return picked, value, nil
+}
+
+func mergeUnits(a, b map[model.MenuItemCode]int) map[model.MenuItemCode]int {
+	merged := make(map[model.MenuItemCode]int, len(a)+len(b))
+	for code, qty := range a {
+		merged[code] += qty
+	}
+	for code, qty := range b {
+		merged[code] += qty
+	}
+	return merged
+}
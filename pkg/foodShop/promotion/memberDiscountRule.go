@@ -0,0 +1,33 @@
+package promotion
+
+import "fmt"
+
+// memberDiscountRule takes the member's tier discount off whatever is left
+// of the bill when it runs, so its position in the pipeline decides what
+// it stacks on.
+type memberDiscountRule struct {
+	code string
+}
+
+func NewMemberDiscountRule(code string) PromotionRule {
+	return &memberDiscountRule{code: code}
+}
+
+func (r *memberDiscountRule) Code() string { return r.code }
+func (r *memberDiscountRule) Kind() Kind   { return KindMember }
+
+func (r *memberDiscountRule) Evaluate(ctx Context) (Outcome, error) {
+	if ctx.MemberDiscountRate <= 0 {
+		return Outcome{}, nil
+	}
+	discount, err := ctx.Running.MulRate(ctx.MemberDiscountRate, ctx.Rounding.Member)
+	if err != nil {
+		return Outcome{}, err
+	}
+	return Outcome{
+		Discount:    discount,
+		Base:        ctx.Running,
+		Rate:        ctx.MemberDiscountRate,
+		Explanation: fmt.Sprintf("member %s off the remaining %s", ctx.MemberDiscountRate, ctx.Running),
+	}, nil
+}
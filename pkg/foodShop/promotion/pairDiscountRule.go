@@ -0,0 +1,68 @@
+package promotion
+
+import (
+	"fmt"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	"github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	"github.com/TewApirat/food-shop/pkg/foodShop/model"
+)
+
+// pairDiscountRule gives DiscountRate off every bundle of BundleSize
+// units of the same eligible code. Codes are never paired with each other.
+type pairDiscountRule struct {
+	code   string
+	policy model.PairDiscountPolicy
+}
+
+func NewPairDiscountRule(code string, policy model.PairDiscountPolicy) PromotionRule {
+	return &pairDiscountRule{
+		code:   code,
+		policy: policy,
+	}
+}
+
+func (r *pairDiscountRule) Code() string { return r.code }
+func (r *pairDiscountRule) Kind() Kind   { return KindPair }
+
+func (r *pairDiscountRule) Evaluate(ctx Context) (Outcome, error) {
+	totalDiscount := domain.Money(0)
+	if r.policy.BundleSize < 1 {
+		return Outcome{}, nil
+	}
+
+	claimed := make(map[model.MenuItemCode]int)
+	var base domain.Money
+	for code := range ctx.QtyByCode {
+		qty := ctx.Available(code)
+		if !r.policy.EligibleCodes[code] || qty < r.policy.BundleSize {
+			continue
+		}
+
+		unitPrice, ok := ctx.PriceByCode[code]
+		if !ok {
+			return Outcome{}, &exception.MenuItemPriceMissingError{Code: code}
+		}
+
+		bundleCount := qty / r.policy.BundleSize
+		bundleValue := unitPrice.MulInt(r.policy.BundleSize)
+		discountPerBundle, err := bundleValue.MulRate(r.policy.DiscountRate, ctx.Rounding.Promotion)
+		if err != nil {
+			return Outcome{}, err
+		}
+
+		totalDiscount = totalDiscount.Add(discountPerBundle.MulInt(bundleCount))
+		claimed[code] = bundleCount * r.policy.BundleSize
+		base = base.Add(bundleValue.MulInt(bundleCount))
+	}
+
+	return Outcome{
+		Discount:     totalDiscount,
+		ClaimedUnits: claimed,
+		Items:        claimed,
+		Base:         base,
+		Rate:         r.policy.DiscountRate,
+		Explanation: fmt.Sprintf("%s off bundles of %d: %s (%s)",
+			r.policy.DiscountRate, r.policy.BundleSize, unitsLabel(claimed), base),
+	}, nil
+}
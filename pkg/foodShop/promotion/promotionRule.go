@@ -0,0 +1,190 @@
+package promotion
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	"github.com/TewApirat/food-shop/pkg/foodShop/model"
+)
+
+// Kind tells QuoteOrder which discount bucket of the quote a rule feeds.
+type Kind string
+
+const (
+	KindPair      Kind = "PAIR"
+	KindMember    Kind = "MEMBER"
+	KindPromotion Kind = "PROMOTION"
+	// KindSpend rules discount the whole bill rather than items; they
+	// share the quote's promotion bucket with KindPromotion.
+	KindSpend Kind = "SPEND"
+)
+
+// Context is the order state a PromotionRule is evaluated against.
+// Running is the amount left after every rule earlier in the pipeline and
+// Claimed counts the units earlier rules already used. ItemDiscount and
+// MemberDiscount sum what earlier item-level and member rules took off.
+// MemberDiscountRate is the tier rate of the member on the order, zero
+// for walk-in customers. Rounding says how rules round percentages; the
+// zero value floors.
+type Context struct {
+	QtyByCode          map[model.MenuItemCode]int
+	PriceByCode        map[model.MenuItemCode]domain.Money
+	Claimed            map[model.MenuItemCode]int
+	Subtotal           domain.Money
+	Running            domain.Money
+	ItemDiscount       domain.Money
+	MemberDiscount     domain.Money
+	MemberDiscountRate domain.Rate
+	Rounding           model.RoundingStrategy
+}
+
+// Available is how many units of code no earlier rule has claimed.
+func (ctx Context) Available(code model.MenuItemCode) int {
+	return ctx.QtyByCode[code] - ctx.Claimed[code]
+}
+
+// Outcome is what a single rule gives the order. FreeUnits lists units the
+// rule made free, by code; they are already included in Discount.
+// ClaimedUnits are the units the rule used, which later item-level rules
+// can no longer discount. NextTier is set by spend-tier rules while a
+// higher tier is still within reach. Items, Base, Rate and Explanation
+// describe the discount for the quote's breakdown: the units it covered,
+// the amount its rate or price was applied to, the rate if there is one
+// and a sentence a cashier can read out.
+type Outcome struct {
+	Discount     domain.Money
+	FreeUnits    map[model.MenuItemCode]int
+	ClaimedUnits map[model.MenuItemCode]int
+	NextTier     *model.SpendTierHint
+	Items        map[model.MenuItemCode]int
+	Base         domain.Money
+	Rate         domain.Rate
+	Explanation  string
+}
+
+type Result struct {
+	Code string
+	Kind Kind
+	Outcome
+}
+
+type PromotionRule interface {
+	Code() string
+	Kind() Kind
+	Evaluate(ctx Context) (Outcome, error)
+}
+
+// GroupRule is a rule that settles several promotions at once because they
+// compete for the same units (e.g. combos), returning one Result each.
+type GroupRule interface {
+	PromotionRule
+	EvaluateGroup(ctx Context) ([]Result, error)
+}
+
+// RewardAdder is implemented by rules that put their reward items into the
+// order themselves. RewardItems returns how many units of each reward code
+// the order should hold for the rule to apply in full.
+type RewardAdder interface {
+	RewardItems(ctx Context) map[model.MenuItemCode]int
+}
+
+// Pipeline evaluates rules in the given order; each rule sees the total
+// left by the rules before it. Caps limit the discount of a promotion, by
+// code.
+type Pipeline struct {
+	rules []PromotionRule
+	caps  map[string]domain.Money
+}
+
+func NewPipeline(rules ...PromotionRule) Pipeline {
+	ownedRules := make([]PromotionRule, len(rules))
+	copy(ownedRules, rules)
+	return Pipeline{rules: ownedRules}
+}
+
+// WithCaps returns a copy of p that limits the discount of each promotion
+// code in caps to the given amount.
+func (p Pipeline) WithCaps(caps map[string]domain.Money) Pipeline {
+	ownedCaps := make(map[string]domain.Money, len(caps))
+	for code, limit := range caps {
+		ownedCaps[code] = limit
+	}
+	p.caps = ownedCaps
+	return p
+}
+
+func (p Pipeline) Rules() []PromotionRule {
+	rules := make([]PromotionRule, len(p.rules))
+	copy(rules, p.rules)
+	return rules
+}
+
+func (p Pipeline) Apply(ctx Context) ([]Result, error) {
+	results := make([]Result, 0, len(p.rules))
+	running := ctx.Subtotal
+	ctx.Claimed = make(map[model.MenuItemCode]int)
+
+	for _, rule := range p.rules {
+		ctx.Running = running
+
+		stepResults, err := evaluateRule(rule, ctx)
+		if err != nil {
+			return nil, fmt.Errorf("evaluate promotion %s: %w", rule.Code(), err)
+		}
+
+		for _, result := range stepResults {
+			if limit, ok := p.caps[result.Code]; ok && result.Discount > limit {
+				result.Discount = limit
+				result.Explanation += fmt.Sprintf(", capped at %s", limit)
+			}
+			if result.Discount > running {
+				result.Discount = running
+				result.Explanation += fmt.Sprintf(", limited to the %s left", running)
+			}
+			running = running.Sub(result.Discount)
+			switch result.Kind {
+			case KindPair, KindPromotion:
+				ctx.ItemDiscount = ctx.ItemDiscount.Add(result.Discount)
+			case KindMember:
+				ctx.MemberDiscount = ctx.MemberDiscount.Add(result.Discount)
+			}
+			for code, qty := range result.ClaimedUnits {
+				ctx.Claimed[code] += qty
+			}
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+func evaluateRule(rule PromotionRule, ctx Context) ([]Result, error) {
+	if group, ok := rule.(GroupRule); ok {
+		return group.EvaluateGroup(ctx)
+	}
+
+	outcome, err := rule.Evaluate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return []Result{{Code: rule.Code(), Kind: rule.Kind(), Outcome: outcome}}, nil
+}
+
+// unitsLabel lists units by code, e.g. "2 x GREEN, 1 x RED".
+func unitsLabel(units map[model.MenuItemCode]int) string {
+	codes := make([]model.MenuItemCode, 0, len(units))
+	for code, qty := range units {
+		if qty > 0 {
+			codes = append(codes, code)
+		}
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+
+	parts := make([]string, len(codes))
+	for i, code := range codes {
+		parts[i] = fmt.Sprintf("%d x %s", units[code], code)
+	}
+	return strings.Join(parts, ", ")
+}
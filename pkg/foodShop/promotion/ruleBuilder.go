@@ -0,0 +1,122 @@
+package promotion
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	"github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	"github.com/TewApirat/food-shop/pkg/foodShop/model"
+)
+
+// BuildRules turns promotion configuration into pipeline rules ordered by
+// ascending Priority. Promotions with equal priority keep their list order.
+// Combo promotions share a single group rule placed where the first combo
+// would run.
+func BuildRules(promotions []model.Promotion) ([]PromotionRule, error) {
+	ordered := make([]model.Promotion, len(promotions))
+	copy(ordered, promotions)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Priority < ordered[j].Priority
+	})
+
+	rules := make([]PromotionRule, 0, len(ordered))
+	var combos []Combo
+	comboAt := -1
+	for _, promo := range ordered {
+		if promo.Type == model.PromotionTypeCombo {
+			if comboAt < 0 {
+				comboAt = len(rules)
+				rules = append(rules, nil)
+			}
+			combos = append(combos, Combo{Code: promo.Code, Policy: model.ComboPolicy{
+				Items: promo.Params.ComboItems,
+				Price: promo.Params.ComboPrice,
+			}})
+			continue
+		}
+
+		rule, err := buildRule(promo)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	if comboAt >= 0 {
+		rules[comboAt] = NewComboGroupRule(combos...)
+	}
+	return rules, nil
+}
+
+// BuildPipeline builds the rules for promotions and applies their
+// stacking discount caps.
+func BuildPipeline(promotions []model.Promotion) (Pipeline, error) {
+	rules, err := BuildRules(promotions)
+	if err != nil {
+		return Pipeline{}, err
+	}
+
+	caps := make(map[string]domain.Money)
+	for _, promo := range promotions {
+		if promo.Stacking.MaxDiscount > 0 {
+			caps[promo.Code] = promo.Stacking.MaxDiscount
+		}
+	}
+	return NewPipeline(rules...).WithCaps(caps), nil
+}
+
+func buildRule(promo model.Promotion) (PromotionRule, error) {
+	switch promo.Type {
+	case model.PromotionTypePair:
+		return NewPairDiscountRule(promo.Code, model.PairDiscountPolicy{
+			EligibleCodes: codeSet(promo.EligibleCodes),
+			DiscountRate:  promo.Params.DiscountRate,
+			BundleSize:    promo.Params.BundleSize,
+		}), nil
+	case model.PromotionTypeMember:
+		return NewMemberDiscountRule(promo.Code), nil
+	case model.PromotionTypeBuyXGetY:
+		policy := model.FreeItemPolicy{
+			EligibleCodes: codeSet(promo.EligibleCodes),
+			BuyQty:        promo.Params.BuyQty,
+			FreeQty:       promo.Params.FreeQty,
+			RewardCodes:   codeSet(promo.Params.RewardCodes),
+		}
+		if promo.Params.AutoAddReward && len(promo.Params.RewardCodes) == 1 {
+			policy.AutoAddReward = promo.Params.RewardCodes[0]
+		}
+		return NewFreeItemRule(promo.Code, policy), nil
+	case model.PromotionTypeNthFree:
+		return NewFreeItemRule(promo.Code, model.FreeItemPolicy{
+			EligibleCodes: codeSet(promo.EligibleCodes),
+			BuyQty:        promo.Params.Nth - 1,
+			FreeQty:       1,
+		}), nil
+	case model.PromotionTypeSpendTier:
+		policy := model.SpendTierPolicy{Base: promo.Params.SpendBase}
+		for _, tier := range promo.Params.Tiers {
+			policy.Tiers = append(policy.Tiers, model.SpendTier{
+				MinSpend:  tier.MinSpend,
+				AmountOff: tier.AmountOff,
+				Rate:      tier.DiscountRate,
+			})
+		}
+		sort.SliceStable(policy.Tiers, func(i, j int) bool {
+			return policy.Tiers[i].MinSpend < policy.Tiers[j].MinSpend
+		})
+		return NewSpendTierRule(promo.Code, policy), nil
+	default:
+		return nil, &exception.InvalidPromotionConfigError{
+			Code:   promo.Code,
+			Reason: fmt.Sprintf("unknown promotion type %q", promo.Type),
+		}
+	}
+}
+
+func codeSet(codes []model.MenuItemCode) map[model.MenuItemCode]bool {
+	set := make(map[model.MenuItemCode]bool, len(codes))
+	for _, code := range codes {
+		set[code] = true
+	}
+	return set
+}
@@ -0,0 +1,78 @@
+package promotion
+
+import (
+	"fmt"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	"github.com/TewApirat/food-shop/pkg/foodShop/model"
+)
+
+// spendTierRule rewards the bill once its base reaches a tier. The base
+// only reflects rules that ran earlier in the pipeline, so an AFTER_MEMBER
+// tier has to be configured after the member promotion.
+type spendTierRule struct {
+	code   string
+	policy model.SpendTierPolicy
+}
+
+// NewSpendTierRule expects policy.Tiers sorted by ascending MinSpend.
+func NewSpendTierRule(code string, policy model.SpendTierPolicy) PromotionRule {
+	return &spendTierRule{
+		code:   code,
+		policy: policy,
+	}
+}
+
+func (r *spendTierRule) Code() string { return r.code }
+func (r *spendTierRule) Kind() Kind   { return KindSpend }
+
+func (r *spendTierRule) Evaluate(ctx Context) (Outcome, error) {
+	base := r.base(ctx)
+
+	var outcome Outcome
+	for _, tier := range r.policy.Tiers {
+		if base < tier.MinSpend {
+			outcome.NextTier = &model.SpendTierHint{
+				PromotionCode: r.code,
+				AmountToGo:    tier.MinSpend.Sub(base),
+				Reward:        tierReward(tier),
+			}
+			break
+		}
+		discount, err := tierDiscount(tier, base, ctx.Rounding.Promotion)
+		if err != nil {
+			return Outcome{}, err
+		}
+		outcome.Discount = discount
+		outcome.Base = base
+		outcome.Rate = tier.Rate
+		outcome.Explanation = fmt.Sprintf("spent %s, reached the %s tier: %s",
+			base, tier.MinSpend, tierReward(tier))
+	}
+	return outcome, nil
+}
+
+func (r *spendTierRule) base(ctx Context) domain.Money {
+	switch r.policy.Base {
+	case model.SpendBaseAfterPair:
+		return ctx.Subtotal.Sub(ctx.ItemDiscount)
+	case model.SpendBaseAfterMember:
+		return ctx.Subtotal.Sub(ctx.ItemDiscount).Sub(ctx.MemberDiscount)
+	default:
+		return ctx.Subtotal
+	}
+}
+
+func tierDiscount(tier model.SpendTier, base domain.Money, mode domain.RoundingMode) (domain.Money, error) {
+	if tier.Rate > 0 {
+		return base.MulRate(tier.Rate, mode)
+	}
+	return tier.AmountOff, nil
+}
+
+func tierReward(tier model.SpendTier) string {
+	if tier.Rate > 0 {
+		return tier.Rate.String() + " off"
+	}
+	return tier.AmountOff.String() + " off"
+}
@@ -0,0 +1,113 @@
+package promotion
+
+import (
+	"fmt"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	"github.com/TewApirat/food-shop/pkg/foodShop/model"
+)
+
+// maxStackingCandidates bounds how many promotions with stacking rules the
+// optimizer chooses between, since it looks at every combination of them.
+const maxStackingCandidates = 16
+
+// Combinations lists every way the stacking policy lets promotions be
+// applied together. Promotions without stacking rules are in every
+// combination; the others are chosen so that no compatible promotion is
+// left out. Combinations keep the input order of their promotions and come
+// in order of preference: the earlier a combination is, the more of the
+// first-listed promotions it keeps.
+func Combinations(promotions []model.Promotion) ([][]model.Promotion, error) {
+	var candidates []int
+	for i, promo := range promotions {
+		if isConstrained(promo, promotions) {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) > maxStackingCandidates {
+		return nil, &exception.InvalidPromotionConfigError{
+			Code:   promotions[candidates[maxStackingCandidates]].Code,
+			Reason: fmt.Sprintf("at most %d active promotions can have stacking rules", maxStackingCandidates),
+		}
+	}
+
+	var combinations [][]model.Promotion
+	chosen := make(map[int]bool, len(candidates))
+	var walk func(next int)
+	walk = func(next int) {
+		if next == len(candidates) {
+			if !maximal(promotions, candidates, chosen) {
+				return
+			}
+			combination := make([]model.Promotion, 0, len(promotions))
+			for i, promo := range promotions {
+				if chosen[i] || !isConstrained(promo, promotions) {
+					combination = append(combination, promo)
+				}
+			}
+			combinations = append(combinations, combination)
+			return
+		}
+
+		i := candidates[next]
+		if compatibleWithChosen(promotions, i, chosen) {
+			chosen[i] = true
+			walk(next + 1)
+			delete(chosen, i)
+		}
+		walk(next + 1)
+	}
+	walk(0)
+
+	return combinations, nil
+}
+
+func isConstrained(promo model.Promotion, promotions []model.Promotion) bool {
+	if promo.Stacking.Constrained() {
+		return true
+	}
+	for _, other := range promotions {
+		for _, code := range other.Stacking.CannotCombineWith {
+			if code == promo.Code {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func compatibleWithChosen(promotions []model.Promotion, i int, chosen map[int]bool) bool {
+	for j := range chosen {
+		if !compatible(promotions[i], promotions[j]) {
+			return false
+		}
+	}
+	return true
+}
+
+// maximal reports whether no left-out candidate could join chosen.
+func maximal(promotions []model.Promotion, candidates []int, chosen map[int]bool) bool {
+	for _, i := range candidates {
+		if !chosen[i] && compatibleWithChosen(promotions, i, chosen) {
+			return false
+		}
+	}
+	return true
+}
+
+func compatible(a, b model.Promotion) bool {
+	if a.Stacking.ExclusiveGroup != "" && a.Stacking.ExclusiveGroup == b.Stacking.ExclusiveGroup {
+		return false
+	}
+	for _, code := range a.Stacking.CannotCombineWith {
+		if code == b.Code {
+			return false
+		}
+	}
+	for _, code := range b.Stacking.CannotCombineWith {
+		if code == a.Code {
+			return false
+		}
+	}
+	return true
+}
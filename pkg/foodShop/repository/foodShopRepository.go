@@ -1,9 +1,17 @@
 package repository
 
-import "github.com/TewApirat/food-shop/pkg/foodShop/model"
+import (
+	"time"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/model"
+)
 
 type FoodShopRepository interface {
 	ListMenuItems() ([]model.MenuItem, error)
+	ListMenuItemsBy(filter model.MenuFilter) ([]model.MenuItem, error)
 	FindMenuItemByCode(code model.MenuItemCode) (model.MenuItem, error)
+	AddMenuItem(item model.MenuItem) (model.MenuItem, error)
+	UpdateMenuItem(update model.MenuItemUpdate) (model.MenuItem, error)
+	RetireMenuItem(code model.MenuItemCode, at time.Time) (model.MenuItem, error)
 	ListPromotions() ([]model.Promotion, error)
 }
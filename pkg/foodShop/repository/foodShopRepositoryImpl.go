@@ -2,6 +2,8 @@ package repository
 
 import (
 	"sort"
+	"sync"
+	"time"
 
 	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
 	"github.com/TewApirat/food-shop/pkg/foodShop/exception"
@@ -9,6 +11,7 @@ import (
 )
 
 type foodShopRepositoryImpl struct {
+	mu    sync.RWMutex
 	menu  map[model.MenuItemCode]model.MenuItem
 	promo []model.Promotion
 }
@@ -20,11 +23,13 @@ func NewFoodShopRepositoryImpl(
 
 	ownedMenu := make(map[model.MenuItemCode]model.MenuItem, len(menu))
 	for code, menuItem := range menu {
-		ownedMenu[code] = menuItem
+		ownedMenu[code] = menuItem.Clone()
 	}
 
 	ownedPromotions := make([]model.Promotion, len(promo))
-	copy(ownedPromotions, promo)
+	for i, promotion := range promo {
+		ownedPromotions[i] = promotion.Clone()
+	}
 
 	return &foodShopRepositoryImpl{
 		menu:  ownedMenu,
@@ -33,15 +38,120 @@ func NewFoodShopRepositoryImpl(
 }
 
 
+// DefaultMenu is the built-in menu: the sets plus drinks and desserts.
+// RED and THAITEA come with options; SUNRISE is only sold before 11:00 and
+// FAMILY only on weekends.
 func DefaultMenu() map[model.MenuItemCode]model.MenuItem {
 	return map[model.MenuItemCode]model.MenuItem{
-		"RED":    {Code: "RED", Name: "Red set", Price: domain.THB(50)},
-		"GREEN":  {Code: "GREEN", Name: "Green set", Price: domain.THB(40)},
-		"BLUE":   {Code: "BLUE", Name: "Blue set", Price: domain.THB(30)},
-		"YELLOW": {Code: "YELLOW", Name: "Yellow set", Price: domain.THB(50)},
-		"PINK":   {Code: "PINK", Name: "Pink set", Price: domain.THB(80)},
-		"PURPLE": {Code: "PURPLE", Name: "Purple set", Price: domain.THB(90)},
-		"ORANGE": {Code: "ORANGE", Name: "Orange set", Price: domain.THB(120)},
+		"RED": {
+			Code: "RED", Name: "Red set", Price: domain.THB(50),
+			Category: model.MenuCategorySet, DisplayOrder: 10,
+			Description: "Tom yum goong with jasmine rice",
+			Tags:        []model.MenuTag{model.MenuTagSpicy, model.MenuTagBestseller},
+			Dietary:     []model.DietaryFlag{model.DietaryHalal},
+			Modifiers: []model.ModifierGroup{
+				{Code: "SIZE", Name: "Size", MaxSelections: 1, Options: []model.ModifierOption{
+					{Code: "LARGE", Name: "Large", PriceDelta: domain.THB(15)},
+				}},
+				{Code: "EXTRAS", Name: "Extras", MaxSelections: 3, Options: []model.ModifierOption{
+					{Code: "EXTRA_EGG", Name: "Extra egg", PriceDelta: domain.THB(10)},
+					{Code: "EXTRA_RICE", Name: "Extra rice", PriceDelta: domain.THB(5)},
+					{Code: "EXTRA_SHRIMP", Name: "Extra shrimp", PriceDelta: domain.THB(25)},
+				}},
+				{Code: "CHILI", Name: "Chili", MaxSelections: 1, Options: []model.ModifierOption{
+					{Code: "NO_CHILI", Name: "No chili"},
+					{Code: "EXTRA_CHILI", Name: "Extra chili"},
+				}},
+			},
+		},
+		"GREEN": {
+			Code: "GREEN", Name: "Green set", Price: domain.THB(40),
+			Category: model.MenuCategorySet, DisplayOrder: 20,
+			Description: "Green curry with tofu and rice",
+			Tags:        []model.MenuTag{model.MenuTagSpicy},
+			Dietary:     []model.DietaryFlag{model.DietaryVegetarian},
+		},
+		"BLUE": {
+			Code: "BLUE", Name: "Blue set", Price: domain.THB(30),
+			Category: model.MenuCategorySet, DisplayOrder: 30,
+			Description: "Butterfly pea rice with fried egg",
+			Dietary:     []model.DietaryFlag{model.DietaryVegetarian},
+		},
+		"YELLOW": {
+			Code: "YELLOW", Name: "Yellow set", Price: domain.THB(50),
+			Category: model.MenuCategorySet, DisplayOrder: 40,
+			Description: "Chicken massaman curry with rice",
+			Dietary:     []model.DietaryFlag{model.DietaryHalal, model.DietaryContainsPeanuts},
+		},
+		"PINK": {
+			Code: "PINK", Name: "Pink set", Price: domain.THB(80),
+			Category: model.MenuCategorySet, DisplayOrder: 50,
+			Description: "Yen ta fo noodles with fish balls",
+			Tags:        []model.MenuTag{model.MenuTagNew},
+		},
+		"PURPLE": {
+			Code: "PURPLE", Name: "Purple set", Price: domain.THB(90),
+			Category: model.MenuCategorySet, DisplayOrder: 60,
+			Description: "Grilled pork neck with riceberry rice",
+		},
+		"ORANGE": {
+			Code: "ORANGE", Name: "Orange set", Price: domain.THB(120),
+			Category: model.MenuCategorySet, DisplayOrder: 70,
+			Description: "Pad thai with river prawns",
+			Tags:        []model.MenuTag{model.MenuTagBestseller},
+			Dietary:     []model.DietaryFlag{model.DietaryContainsPeanuts},
+		},
+		"SUNRISE": {
+			Code: "SUNRISE", Name: "Sunrise set", Price: domain.THB(45),
+			Category: model.MenuCategorySet, DisplayOrder: 80,
+			Description:  "Rice porridge with minced pork and a soft-boiled egg",
+			Availability: &model.Schedule{TimeFrom: "06:00", TimeTo: "11:00"},
+		},
+		"FAMILY": {
+			Code: "FAMILY", Name: "Family set", Price: domain.THB(199),
+			Category: model.MenuCategorySet, DisplayOrder: 90,
+			Description:  "Sharing platter for four with three curries and rice",
+			Dietary:      []model.DietaryFlag{model.DietaryContainsPeanuts},
+			Availability: &model.Schedule{Days: []string{"SAT", "SUN"}},
+		},
+		"THAITEA": {
+			Code: "THAITEA", Name: "Thai tea", Price: domain.THB(35),
+			Category: model.MenuCategoryDrink, DisplayOrder: 10,
+			Description: "Iced Thai milk tea",
+			Tags:        []model.MenuTag{model.MenuTagBestseller},
+			Dietary:     []model.DietaryFlag{model.DietaryVegetarian, model.DietaryHalal},
+			Modifiers: []model.ModifierGroup{
+				{Code: "SWEETNESS", Name: "Sweetness", MinSelections: 1, MaxSelections: 1, Options: []model.ModifierOption{
+					{Code: "SWEET_0", Name: "No sugar"},
+					{Code: "SWEET_50", Name: "Half sweet"},
+					{Code: "SWEET_100", Name: "Regular sweet"},
+				}},
+				{Code: "TOPPINGS", Name: "Toppings", Options: []model.ModifierOption{
+					{Code: "PEARLS", Name: "Pearls", PriceDelta: domain.THB(10)},
+					{Code: "CHEESE_FOAM", Name: "Cheese foam", PriceDelta: domain.THB(15)},
+				}},
+			},
+		},
+		"LIMESODA": {
+			Code: "LIMESODA", Name: "Lime soda", Price: domain.THB(30),
+			Category: model.MenuCategoryDrink, DisplayOrder: 20,
+			Description: "Fresh lime with soda and honey",
+			Tags:        []model.MenuTag{model.MenuTagNew},
+			Dietary:     []model.DietaryFlag{model.DietaryVegetarian, model.DietaryHalal},
+		},
+		"MANGO": {
+			Code: "MANGO", Name: "Mango rice", Price: domain.THB(60),
+			Category: model.MenuCategoryDessert, DisplayOrder: 10,
+			Description: "Mango sticky rice with coconut cream",
+			Dietary:     []model.DietaryFlag{model.DietaryVegetarian, model.DietaryHalal},
+		},
+		"ROTI": {
+			Code: "ROTI", Name: "Banana roti", Price: domain.THB(45),
+			Category: model.MenuCategoryDessert, DisplayOrder: 20,
+			Description: "Banana roti with condensed milk and crushed peanuts",
+			Tags:        []model.MenuTag{model.MenuTagNew},
+			Dietary:     []model.DietaryFlag{model.DietaryVegetarian, model.DietaryContainsPeanuts},
+		},
 	}
 }
 
@@ -49,13 +159,19 @@ func DefaultPromotions() []model.Promotion {
 	return []model.Promotion{
 		{
 			Code:        "MEMBER",
-			Title:       "Member card 10% off",
-			Description: "Get 10% discount on the total bill if customer has a member card.",
+			Type:        model.PromotionTypeMember,
+			Title:       "Member tiers: Silver 5% / Gold 10% / Platinum 15%",
+			Description: "Members get their tier's discount on the total bill after item promotions.",
+			Priority:    20,
 		},
 		{
-			Code:        "PAIR",
-			Title:       "Pair discount 5% (ORANGE/PINK/GREEN)",
-			Description: "Every pair (2 items of the same code) for ORANGE/PINK/GREEN gets 5% off that pair value.",
+			Code:          "PAIR",
+			Type:          model.PromotionTypePair,
+			Title:         "Pair discount 5% (ORANGE/PINK/GREEN)",
+			Description:   "Every pair (2 items of the same code) for ORANGE/PINK/GREEN gets 5% off that pair value.",
+			Priority:      10,
+			EligibleCodes: []model.MenuItemCode{"ORANGE", "PINK", "GREEN"},
+			Params:        model.PromotionParams{DiscountRate: domain.PercentRate(5), BundleSize: 2},
 		},
 	}
 }
@@ -65,28 +181,139 @@ func NewFoodShopRepositoryDefault() FoodShopRepository {
 }
 
 func (r *foodShopRepositoryImpl) ListMenuItems() ([]model.MenuItem, error) {
+	return r.ListMenuItemsBy(model.MenuFilter{})
+}
+
+// ListMenuItemsBy returns the items matching filter in menu order:
+// category, then display order, then code.
+func (r *foodShopRepositoryImpl) ListMenuItemsBy(filter model.MenuFilter) ([]model.MenuItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	menuItems := make([]model.MenuItem, 0, len(r.menu))
 	for _, menuItem := range r.menu {
-		menuItems = append(menuItems, menuItem)
+		if filter.Matches(menuItem) {
+			menuItems = append(menuItems, menuItem.Clone())
+		}
 	}
 
 	sort.Slice(menuItems, func(i, j int) bool {
-		return menuItems[i].Code < menuItems[j].Code
+		a, b := menuItems[i], menuItems[j]
+		if a.Category.Rank() != b.Category.Rank() {
+			return a.Category.Rank() < b.Category.Rank()
+		}
+		if a.Category != b.Category {
+			return a.Category < b.Category
+		}
+		if a.DisplayOrder != b.DisplayOrder {
+			return a.DisplayOrder < b.DisplayOrder
+		}
+		return a.Code < b.Code
 	})
 
 	return menuItems, nil
 }
 
+// FindMenuItemByCode finds retired items too, so past orders still
+// resolve.
 func (r *foodShopRepositoryImpl) FindMenuItemByCode(code model.MenuItemCode) (model.MenuItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	menuItems, ok := r.menu[code]
 	if !ok {
 		return model.MenuItem{}, exception.UnknownMenuItemError{Code: string(code)}
 	}
-	return menuItems, nil
+	return menuItems.Clone(), nil
+}
+
+// AddMenuItem validates a new item and adds it to the menu. Codes are
+// never reused, not even those of retired items. Price changes given with
+// the item are numbered from version 2 in order.
+func (r *foodShopRepositoryImpl) AddMenuItem(item model.MenuItem) (model.MenuItem, error) {
+	item = item.Clone()
+	item.RetiredAt = time.Time{}
+	for i := range item.PriceChanges {
+		item.PriceChanges[i].Version = i + 2
+	}
+	if err := validateMenuItem(item); err != nil {
+		return model.MenuItem{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.menu[item.Code]; ok {
+		return model.MenuItem{}, &exception.DuplicateMenuItemError{Code: string(item.Code)}
+	}
+	r.menu[item.Code] = item
+	return item.Clone(), nil
+}
+
+// UpdateMenuItem renames an item on the menu and/or adds its next price
+// version. Past versions are kept so earlier prices can still be looked
+// up, and a new version must take effect after the latest one.
+func (r *foodShopRepositoryImpl) UpdateMenuItem(update model.MenuItemUpdate) (model.MenuItem, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, err := r.findActiveLocked(update.Code)
+	if err != nil {
+		return model.MenuItem{}, err
+	}
+
+	if update.Name != nil {
+		item.Name = *update.Name
+	}
+	if update.Price != nil {
+		if update.EffectiveFrom.IsZero() {
+			return model.MenuItem{}, &exception.InvalidMenuItemError{Code: string(item.Code), Reason: "a new price needs effectiveFrom"}
+		}
+		item.PriceChanges = append(item.PriceChanges, model.PriceVersion{
+			Version:       len(item.PriceChanges) + 2,
+			Price:         *update.Price,
+			EffectiveFrom: update.EffectiveFrom,
+		})
+	}
+	if err := validateMenuItem(item); err != nil {
+		return model.MenuItem{}, err
+	}
+
+	r.menu[item.Code] = item
+	return item.Clone(), nil
+}
+
+// RetireMenuItem takes an item off the menu at the given time. It can no
+// longer be ordered or changed but is still found by code.
+func (r *foodShopRepositoryImpl) RetireMenuItem(code model.MenuItemCode, at time.Time) (model.MenuItem, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, err := r.findActiveLocked(code)
+	if err != nil {
+		return model.MenuItem{}, err
+	}
+
+	item.RetiredAt = at
+	r.menu[code] = item
+	return item.Clone(), nil
+}
+
+func (r *foodShopRepositoryImpl) findActiveLocked(code model.MenuItemCode) (model.MenuItem, error) {
+	item, ok := r.menu[code]
+	if !ok {
+		return model.MenuItem{}, exception.UnknownMenuItemError{Code: string(code)}
+	}
+	if item.Retired() {
+		return model.MenuItem{}, &exception.MenuItemRetiredError{Code: string(code), RetiredAt: item.RetiredAt}
+	}
+	return item.Clone(), nil
 }
 
 func (r *foodShopRepositoryImpl) ListPromotions() ([]model.Promotion, error) {
 	promotions := make([]model.Promotion, len(r.promo))
-	copy(promotions, r.promo)
+	for i, promotion := range r.promo {
+		promotions[i] = promotion.Clone()
+	}
 	return promotions, nil
 }
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/stretchr/testify/mock"
 
 	"github.com/TewApirat/food-shop/pkg/foodShop/model"
@@ -20,6 +22,26 @@ func (m *FoodShopRepositoryMock) ListMenuItems() ([]model.MenuItem, error) {
 	return args.Get(0).([]model.MenuItem), args.Error(1)
 }
 
+func (m *FoodShopRepositoryMock) ListMenuItemsBy(filter model.MenuFilter) ([]model.MenuItem, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]model.MenuItem), args.Error(1)
+}
+
+func (m *FoodShopRepositoryMock) AddMenuItem(item model.MenuItem) (model.MenuItem, error) {
+	args := m.Called(item)
+	return args.Get(0).(model.MenuItem), args.Error(1)
+}
+
+func (m *FoodShopRepositoryMock) UpdateMenuItem(update model.MenuItemUpdate) (model.MenuItem, error) {
+	args := m.Called(update)
+	return args.Get(0).(model.MenuItem), args.Error(1)
+}
+
+func (m *FoodShopRepositoryMock) RetireMenuItem(code model.MenuItemCode, at time.Time) (model.MenuItem, error) {
+	args := m.Called(code, at)
+	return args.Get(0).(model.MenuItem), args.Error(1)
+}
+
 func (m *FoodShopRepositoryMock) ListPromotions() ([]model.Promotion, error) {
 	args := m.Called()
 	return args.Get(0).([]model.Promotion), args.Error(1)
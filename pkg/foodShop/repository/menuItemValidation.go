@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	"github.com/TewApirat/food-shop/pkg/foodShop/model"
+)
+
+// menuItemCodePattern is an upper-case letter followed by up to 15
+// upper-case letters, digits or underscores, e.g. RED or THAITEA_L.
+var menuItemCodePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]{0,15}$`)
+
+func validateMenuItem(item model.MenuItem) error {
+	code := string(item.Code)
+	if !menuItemCodePattern.MatchString(code) {
+		return &exception.InvalidMenuItemError{
+			Code:   code,
+			Reason: "code must be 1-16 upper-case letters, digits or underscores, starting with a letter",
+		}
+	}
+	if strings.TrimSpace(item.Name) == "" {
+		return &exception.InvalidMenuItemError{Code: code, Reason: "name is required"}
+	}
+	if item.Price < 0 {
+		return &exception.InvalidMenuItemError{Code: code, Reason: "price must be >= 0"}
+	}
+	var latest time.Time
+	for _, version := range item.PriceChanges {
+		if version.Price < 0 {
+			return &exception.InvalidMenuItemError{Code: code, Reason: "price must be >= 0"}
+		}
+		if version.EffectiveFrom.IsZero() {
+			return &exception.InvalidMenuItemError{Code: code, Reason: "a new price needs effectiveFrom"}
+		}
+		if !version.EffectiveFrom.After(latest) {
+			return &exception.InvalidMenuItemError{
+				Code:   code,
+				Reason: fmt.Sprintf("price changes must take effect after the latest one (%s)", latest.Format("2006-01-02 15:04")),
+			}
+		}
+		latest = version.EffectiveFrom
+	}
+	if item.Category != "" && item.Category.Rank() == len(model.MenuCategories()) {
+		return &exception.InvalidMenuItemError{Code: code, Reason: fmt.Sprintf("unknown category %s", item.Category)}
+	}
+	if item.Availability != nil {
+		if err := item.Availability.Validate(); err != nil {
+			return &exception.InvalidMenuItemError{Code: code, Reason: "availability: " + err.Error()}
+		}
+	}
+
+	optionCodes := make(map[string]bool)
+	for _, group := range item.Modifiers {
+		if group.MinSelections < 0 || group.MaxSelections < 0 {
+			return &exception.InvalidMenuItemError{Code: code, Reason: fmt.Sprintf("modifier group %s: selections must be >= 0", group.Code)}
+		}
+		if group.MaxSelections > 0 && group.MinSelections > group.MaxSelections {
+			return &exception.InvalidMenuItemError{Code: code, Reason: fmt.Sprintf("modifier group %s: minSelections is above maxSelections", group.Code)}
+		}
+		for _, option := range group.Options {
+			if optionCodes[option.Code] {
+				return &exception.InvalidMenuItemError{Code: code, Reason: fmt.Sprintf("option %s is listed twice", option.Code)}
+			}
+			optionCodes[option.Code] = true
+		}
+	}
+	return nil
+}
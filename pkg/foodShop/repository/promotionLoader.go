@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	"github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	"github.com/TewApirat/food-shop/pkg/foodShop/model"
+)
+
+// LoadPromotionsFile reads a JSON array of promotions, e.g. config/promotions.json.
+func LoadPromotionsFile(path string) ([]model.Promotion, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read promotions file %s: %w", path, err)
+	}
+	return ParsePromotions(data)
+}
+
+func ParsePromotions(data []byte) ([]model.Promotion, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var promotions []model.Promotion
+	if err := decoder.Decode(&promotions); err != nil {
+		return nil, &exception.InvalidPromotionConfigError{Reason: err.Error()}
+	}
+
+	seen := make(map[string]bool, len(promotions))
+	for i := range promotions {
+		promo := &promotions[i]
+		promo.Code = strings.ToUpper(strings.TrimSpace(promo.Code))
+		normalizeCodes(promo.EligibleCodes)
+		normalizeCodes(promo.Params.RewardCodes)
+		if promo.Params.ComboItems != nil {
+			items := make(map[model.MenuItemCode]int, len(promo.Params.ComboItems))
+			for code, qty := range promo.Params.ComboItems {
+				items[model.MenuItemCode(strings.ToUpper(strings.TrimSpace(string(code))))] += qty
+			}
+			promo.Params.ComboItems = items
+		}
+		promo.Stacking.ExclusiveGroup = strings.ToUpper(strings.TrimSpace(promo.Stacking.ExclusiveGroup))
+		for j, code := range promo.Stacking.CannotCombineWith {
+			promo.Stacking.CannotCombineWith[j] = strings.ToUpper(strings.TrimSpace(code))
+		}
+
+		if err := validatePromotion(*promo); err != nil {
+			return nil, err
+		}
+		if seen[promo.Code] {
+			return nil, &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: "duplicate promotion code"}
+		}
+		seen[promo.Code] = true
+	}
+
+	for _, promo := range promotions {
+		for _, code := range promo.Stacking.CannotCombineWith {
+			if !seen[code] {
+				return nil, &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: fmt.Sprintf("cannotCombineWith refers to unknown promotion %s", code)}
+			}
+		}
+	}
+
+	return promotions, nil
+}
+
+func validatePromotion(promo model.Promotion) error {
+	if promo.Code == "" {
+		return &exception.InvalidPromotionConfigError{Reason: "promotion code is required"}
+	}
+	if promo.Params.DiscountRate < 0 || promo.Params.DiscountRate > domain.BasisPointsPerWhole {
+		return &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: "discountPercent must be between 0 and 100"}
+	}
+
+	if promo.Stacking.MaxDiscount < 0 {
+		return &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: "maxDiscount must be >= 0"}
+	}
+	for _, code := range promo.Stacking.CannotCombineWith {
+		if code == promo.Code {
+			return &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: "a promotion cannot exclude itself"}
+		}
+	}
+
+	if promo.Schedule != nil {
+		if err := promo.Schedule.Validate(); err != nil {
+			return &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: "schedule: " + err.Error()}
+		}
+	}
+
+	switch promo.Type {
+	case model.PromotionTypePair:
+		if len(promo.EligibleCodes) == 0 {
+			return &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: "eligibleCodes is required"}
+		}
+		if promo.Params.BundleSize < 1 {
+			return &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: "bundleSize must be >= 1"}
+		}
+	case model.PromotionTypeMember:
+		if promo.Params.DiscountRate != 0 {
+			return &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: "discountPercent is not allowed, member rates come from the member tier"}
+		}
+	case model.PromotionTypeBuyXGetY:
+		if len(promo.EligibleCodes) == 0 {
+			return &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: "eligibleCodes is required"}
+		}
+		if promo.Params.BuyQty < 1 || promo.Params.FreeQty < 1 {
+			return &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: "buyQty and freeQty must be >= 1"}
+		}
+		for _, reward := range promo.Params.RewardCodes {
+			for _, code := range promo.EligibleCodes {
+				if reward == code {
+					return &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: fmt.Sprintf("%s cannot be both eligible and a reward", code)}
+				}
+			}
+		}
+		if promo.Params.AutoAddReward && len(promo.Params.RewardCodes) != 1 {
+			return &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: "autoAddReward needs exactly one reward code"}
+		}
+	case model.PromotionTypeNthFree:
+		if len(promo.EligibleCodes) == 0 {
+			return &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: "eligibleCodes is required"}
+		}
+		if promo.Params.Nth < 2 {
+			return &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: "nth must be >= 2"}
+		}
+	case model.PromotionTypeCombo:
+		units := 0
+		for code, qty := range promo.Params.ComboItems {
+			if qty < 1 {
+				return &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: fmt.Sprintf("comboItems %s qty must be >= 1", code)}
+			}
+			units += qty
+		}
+		if units < 2 {
+			return &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: "comboItems must hold at least 2 units"}
+		}
+		if promo.Params.ComboPrice < 0 {
+			return &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: "comboPrice must be >= 0"}
+		}
+	case model.PromotionTypeSpendTier:
+		if err := validateSpendTiers(promo); err != nil {
+			return err
+		}
+	default:
+		return &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: fmt.Sprintf("unknown promotion type %q", promo.Type)}
+	}
+
+	return nil
+}
+
+func validateSpendTiers(promo model.Promotion) error {
+	switch promo.Params.SpendBase {
+	case "", model.SpendBaseSubtotal, model.SpendBaseAfterPair, model.SpendBaseAfterMember:
+	default:
+		return &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: fmt.Sprintf("unknown base %q", promo.Params.SpendBase)}
+	}
+	if len(promo.Params.Tiers) == 0 {
+		return &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: "tiers is required"}
+	}
+
+	seen := make(map[domain.Money]bool, len(promo.Params.Tiers))
+	for _, tier := range promo.Params.Tiers {
+		if tier.MinSpend <= 0 {
+			return &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: "tier minSpend must be > 0"}
+		}
+		if seen[tier.MinSpend] {
+			return &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: fmt.Sprintf("duplicate tier minSpend %s", tier.MinSpend)}
+		}
+		seen[tier.MinSpend] = true
+		if (tier.AmountOff > 0) == (tier.DiscountRate > 0) {
+			return &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: "each tier needs exactly one of amountOff or discountPercent"}
+		}
+		if tier.AmountOff < 0 || tier.DiscountRate < 0 || tier.DiscountRate > domain.BasisPointsPerWhole {
+			return &exception.InvalidPromotionConfigError{Code: promo.Code, Reason: "tier reward out of range"}
+		}
+	}
+	return nil
+}
+
+func normalizeCodes(codes []model.MenuItemCode) {
+	for i, code := range codes {
+		codes[i] = model.MenuItemCode(strings.ToUpper(strings.TrimSpace(string(code))))
+	}
+}
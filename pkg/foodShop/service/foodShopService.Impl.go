@@ -1,44 +1,66 @@
 package service
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"strings"
-	"time"
+	"sync"
 
+	_couponRepository "github.com/TewApirat/food-shop/pkg/coupon/repository"
+	_exchangeRateRepository "github.com/TewApirat/food-shop/pkg/exchangeRate/repository"
 	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
 	_foodShopException "github.com/TewApirat/food-shop/pkg/foodShop/exception"
 	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopPromotion "github.com/TewApirat/food-shop/pkg/foodShop/promotion"
 	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_inventoryRepository "github.com/TewApirat/food-shop/pkg/inventory/repository"
+	_memberRepository "github.com/TewApirat/food-shop/pkg/member/repository"
 	_orderHistoryModel "github.com/TewApirat/food-shop/pkg/orderHistory/model"
 	_orderHistoryReppsitory "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
-)
-
-var pairDiscountEligibleCodes = map[_foodShopModel.MenuItemCode]bool{
-	"ORANGE": true,
-	"PINK":   true,
-	"GREEN":  true,
-}
-
-const (
-	pairBundleSize          = 2
-	pairDiscountPercent     = 5
-	memberDiscountPercent   = 10
+	_taxInvoiceModel "github.com/TewApirat/food-shop/pkg/taxInvoice/model"
+	_taxInvoiceRepository "github.com/TewApirat/food-shop/pkg/taxInvoice/repository"
 )
 
 type foodShopServiceImpl struct {
-	foodShopRepository _foodShopRepository.FoodShopRepository
+	foodShopRepository     _foodShopRepository.FoodShopRepository
 	orderHistoryRepository _orderHistoryReppsitory.OrderHistoryRepository
+	promotionPipeline      *_foodShopPromotion.Pipeline
+	couponRepository       _couponRepository.CouponRepository
+	memberRepository       _memberRepository.MemberRepository
+	exchangeRateRepository _exchangeRateRepository.ExchangeRateRepository
+	taxInvoiceRepository   _taxInvoiceRepository.TaxInvoiceRepository
+	inventoryRepository    _inventoryRepository.InventoryRepository
+	seller                 _taxInvoiceModel.Party
+	clock                  domain.Clock
+	rounding               _foodShopModel.RoundingStrategy
+	tax                    _foodShopModel.TaxPolicy
+	maxOrderValue          domain.Money
+
+	// orderMu guards orderNo and keeps the side effects of one order
+	// together, so concurrent quotes never share an order number. Cancels
+	// and tax invoices hold it too, so an order is never both.
+	orderMu sync.Mutex
 	orderNo int
 }
 
 func NewFoodShopServiceImpl(
 	foodShopRepository _foodShopRepository.FoodShopRepository,
 	orderHistoryRepository _orderHistoryReppsitory.OrderHistoryRepository,
-	) FoodShopService {
-	return &foodShopServiceImpl{
-		foodShopRepository: foodShopRepository,
+	opts ...Option,
+) FoodShopService {
+	s := &foodShopServiceImpl{
+		foodShopRepository:     foodShopRepository,
 		orderHistoryRepository: orderHistoryRepository,
+		clock:                  domain.SystemClock(),
+		rounding:               _foodShopModel.DefaultRoundingStrategy(),
+		maxOrderValue:          DefaultMaxOrderValue,
+		seller:                 _taxInvoiceModel.DefaultSeller(),
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 func (s *foodShopServiceImpl) ListOrderHistory() ([]_orderHistoryModel.OrderHistoryEntry, error) {
@@ -49,46 +71,79 @@ func (s *foodShopServiceImpl) CountOrderHistory() (int, error) {
 	return s.orderHistoryRepository.Count()
 }
 
-
 func (s *foodShopServiceImpl) GetMenuCatalog() ([]_foodShopModel.MenuItem, error) {
 	return s.foodShopRepository.ListMenuItems()
 }
 
-func (s *foodShopServiceImpl) GetPromotions() ([]_foodShopModel.Promotion, error) {
-	return s.foodShopRepository.ListPromotions()
+func (s *foodShopServiceImpl) FilterMenuCatalog(filter _foodShopModel.MenuFilter) ([]_foodShopModel.MenuItem, error) {
+	return s.foodShopRepository.ListMenuItemsBy(filter)
 }
 
-// QuoteOrder workflow
-// 1) Validate request
-// 2) Prepare state for calculation / promotion rules
-// 3) Process each input item (rawCode -> qty)
-// 4) Calculate pair discount (policy-level discount)
-// 5) Apply discounts in correct order
-// 6) Persist order history (side effect)
-// 7) Return quote result
+// GetPromotions lists every configured promotion, flagging the ones whose
+// schedule is not open right now.
+func (s *foodShopServiceImpl) GetPromotions() ([]_foodShopModel.PromotionStatus, error) {
+	promotions, err := s.foodShopRepository.ListPromotions()
+	if err != nil {
+		return nil, err
+	}
 
+	now := s.clock.Now()
+	statuses := make([]_foodShopModel.PromotionStatus, 0, len(promotions))
+	for _, promo := range promotions {
+		active, err := promo.ActiveAt(now)
+		if err != nil {
+			return nil, fmt.Errorf("promotion %s schedule: %w", promo.Code, err)
+		}
+		statuses = append(statuses, _foodShopModel.PromotionStatus{Promotion: promo, Active: active})
+	}
+	return statuses, nil
+}
 
+// QuoteOrder workflow
+// 1) Validate request
+// 2) Resolve the member and prepare state for calculation / promotion rules
+// 3) Process each input item (rawCode -> qty) and request line, rejecting
+//    retired items and those not available right now, pricing it at the
+//    menu price in effect now plus its options and rejecting
+//    amounts that overflow or go over the maximum order value
+// 4) Run every combination of promotions the stacking policy allows
+//    (reward items added, rules in priority order) and keep the one with
+//    the lowest total
+// 5) Sum the chosen rule results into the quote's discount buckets
+// 6) Apply coupons on what is left after promotions and allocate every
+//    discount down to the order lines
+// 7) Add the service charge and VAT, then convert the grand total for
+//    display when another currency was asked for
+// 8) Number the order, reserve stock, redeem coupons and persist order
+//    history (side effects, one order at a time); stock is put back if
+//    the coupons cannot be redeemed
+// 9) Return quote result
 
 func (s *foodShopServiceImpl) QuoteOrder(req _foodShopModel.PurchasingRequest) (_foodShopModel.OrderQuote, error) {
-	if len(req.Items) == 0 {
+	if len(req.Items) == 0 && len(req.Lines) == 0 {
 		return _foodShopModel.OrderQuote{}, &_foodShopException.EmptyOrderError{}
 	}
 
+	now := s.clock.Now()
+
 	qtyByCode := make(map[_foodShopModel.MenuItemCode]int)
 	priceByCode := make(map[_foodShopModel.MenuItemCode]domain.Money)
 
+	requested := make([]_foodShopModel.RequestLine, 0, len(req.Items)+len(req.Lines))
+	for rawCode, qty := range req.Items {
+		requested = append(requested, _foodShopModel.RequestLine{Code: rawCode, Qty: qty})
+	}
+	requested = append(requested, req.Lines...)
 
+	lines := make([]_foodShopModel.OrderLine, 0, len(requested))
 
-	lines := make([]_foodShopModel.OrderLine, 0, len(req.Items))
-
-	var subtotal domain.Money
-
-	for rawCode, qty := range req.Items {
+	for _, reqLine := range requested {
+		qty := reqLine.Qty
 		if qty < 1 {
 			return _foodShopModel.OrderQuote{}, &_foodShopException.InvalidQuantityError{Qty: qty}
 		}
 
-		code, err := normalizeItemCode(rawCode)
+		code, err := normalizeItemCode(reqLine.Code)
 		if err != nil {
 			return _foodShopModel.OrderQuote{}, err
 		}
@@ -97,97 +152,192 @@ func (s *foodShopServiceImpl) QuoteOrder(req _foodShopModel.PurchasingRequest) (
 		if err != nil {
 			return _foodShopModel.OrderQuote{}, fmt.Errorf("find menu item by code %s: %w", code, err)
 		}
+		if err := checkAvailable(menuItem, now); err != nil {
+			return _foodShopModel.OrderQuote{}, err
+		}
 
-		priceByCode[code] = menuItem.Price
-		qtyByCode[code] += qty
-
-
-
+		options, delta, err := selectOptions(menuItem, reqLine.Options)
+		if err != nil {
+			return _foodShopModel.OrderQuote{}, err
+		}
+		menuPrice := menuItem.PriceAt(now)
+		unitPrice, err := menuPrice.Price.AddChecked(delta)
+		if err != nil {
+			return _foodShopModel.OrderQuote{}, amountError("line "+string(code), err)
+		}
+		if unitPrice < 0 {
+			return _foodShopModel.OrderQuote{}, &_foodShopException.InvalidModifierError{
+				Code:   string(code),
+				Reason: "the options take the price below zero",
+			}
+		}
 
-		lineTotal := menuItem.Price.MulInt(qty)
-		subtotal = subtotal.Add(menuItem.Price.MulInt(qty))
+		if qtyByCode[code] > math.MaxInt-qty {
+			return _foodShopModel.OrderQuote{}, &_foodShopException.AmountOverflowError{Step: "quantity of " + string(code)}
+		}
+		// Item promotions work on the menu price; option deltas only get
+		// bill-level discounts.
+		priceByCode[code] = menuPrice.Price
+		qtyByCode[code] += qty
 
+		lineTotal, err := unitPrice.MulIntChecked(qty)
+		if err != nil {
+			return _foodShopModel.OrderQuote{}, amountError("line "+string(code), err)
+		}
+		if err := s.checkOrderValue("line "+string(code), lineTotal); err != nil {
+			return _foodShopModel.OrderQuote{}, err
+		}
 
 		lines = append(lines, _foodShopModel.OrderLine{
 			Code:      code,
 			Name:      menuItem.Name,
 			Qty:       qty,
-			UnitPrice: menuItem.Price,
+			Options:   options,
+			MenuPrice: menuPrice,
+			UnitPrice: unitPrice,
 			LineTotal: lineTotal,
 		})
 	}
 
-	pairDiscount, err := calculatePairDiscount(qtyByCode, priceByCode)
+	member, err := s.resolveMember(req.MemberID, now)
 	if err != nil {
 		return _foodShopModel.OrderQuote{}, err
 	}
 
-	afterPairDiscount := subtotal.Sub(pairDiscount)
-
-	var memberDiscount domain.Money
-	if req.Member {
-		memberDiscount = afterPairDiscount.Percent(memberDiscountPercent)
+	promotionCtx := _foodShopPromotion.Context{
+		QtyByCode:          qtyByCode,
+		PriceByCode:        priceByCode,
+		MemberDiscountRate: member.Tier.DiscountRate(),
+		Rounding:           s.rounding,
 	}
 
-	total := afterPairDiscount.Sub(memberDiscount)
+	applied, rejected, err := s.applyBestPromotions(promotionCtx, lines, now)
+	if err != nil {
+		return _foodShopModel.OrderQuote{}, err
+	}
 
-	s.orderNo++
+	coupons, err := s.applyCoupons(req, applied.lines, applied.applied, applied.total, now)
+	if err != nil {
+		return _foodShopModel.OrderQuote{}, amountError("coupons", err)
+	}
 
-	_ = s.orderHistoryRepository.Add(_orderHistoryModel.OrderHistoryEntry{
-	OrderNo:        s.orderNo,
-	CreatedAt:      time.Now(),
-	Member:         req.Member,
-	Line:          lines,
-	Subtotal:       subtotal,
-	PairDiscount:   pairDiscount,
-	MemberDiscount: memberDiscount,
-	Total:          total,
-	
-})
+	var couponDiscount domain.Money
+	for _, coupon := range coupons {
+		couponDiscount, err = couponDiscount.AddChecked(coupon.Discount)
+		if err != nil {
+			return _foodShopModel.OrderQuote{}, amountError("coupon discount", err)
+		}
+	}
 
+	total, err := applied.total.SubChecked(couponDiscount)
+	if err != nil {
+		return _foodShopModel.OrderQuote{}, amountError("total", err)
+	}
 
-	return _foodShopModel.OrderQuote{
-		Lines:          lines,
-		Subtotal:       subtotal,
-		PairDiscount:   pairDiscount,
-		MemberDiscount: memberDiscount,
-		Total:          total,
-	}, nil
-}
+	if err := allocateLineDiscounts(applied.lines, applied.applied, coupons, s.rounding.Allocation); err != nil {
+		return _foodShopModel.OrderQuote{}, err
+	}
 
-func normalizeItemCode(raw string) (_foodShopModel.MenuItemCode, error) {
-	trimmed := strings.TrimSpace(raw)
-	if trimmed == "" {
-		return "", &_foodShopException.InvalidItemCodeError{}
+	taxed, err := s.applyTaxes(applied.subtotal, total)
+	if err != nil {
+		return _foodShopModel.OrderQuote{}, err
 	}
-	return _foodShopModel.MenuItemCode(strings.ToUpper(trimmed)), nil
-}
 
+	cashTotal := taxed.grandTotal.RoundTo(s.rounding.CashUnit, s.rounding.Cash)
 
-func calculatePairDiscount(
-	qtyByCode map[_foodShopModel.MenuItemCode]int,
-	priceByCode map[_foodShopModel.MenuItemCode]domain.Money,
-) (domain.Money, error) {
+	display, err := s.convertForDisplay(req.DisplayCurrency, taxed.grandTotal)
+	if err != nil {
+		return _foodShopModel.OrderQuote{}, err
+	}
 
-	totalDiscount := domain.Money(0)
+	stock, err := s.placeOrder(_orderHistoryModel.OrderHistoryEntry{
+		CreatedAt:         now,
+		MemberID:          member.ID,
+		MemberTier:        member.Tier,
+		CustomerID:        req.CustomerID,
+		Line:              applied.lines,
+		Subtotal:          applied.subtotal,
+		PairDiscount:      applied.pairDiscount,
+		MemberDiscount:    applied.memberDiscount,
+		PromotionDiscount: applied.promotionDiscount,
+		CouponDiscount:    couponDiscount,
+		AppliedPromotions: applied.applied,
+		Coupons:           coupons,
+		Total:             total,
+		PreTax:            taxed.preTax,
+		ServiceCharge:     taxed.serviceCharge,
+		VAT:               taxed.vat,
+		GrandTotal:        taxed.grandTotal,
+		Tax:               s.tax,
+		CashRounding:      cashTotal.Sub(taxed.grandTotal),
+		CashTotal:         cashTotal,
+		Rounding:          s.rounding,
+		Display:           display,
+	})
+	if err != nil {
+		return _foodShopModel.OrderQuote{}, err
+	}
 
-	for code, qty := range qtyByCode {
-		if !pairDiscountEligibleCodes[code] || qty < pairBundleSize {
-			continue
-		}
+	return _foodShopModel.OrderQuote{
+		Lines:                applied.lines,
+		MemberID:             member.ID,
+		MemberTier:           member.Tier,
+		Subtotal:             applied.subtotal,
+		PairDiscount:         applied.pairDiscount,
+		MemberDiscount:       applied.memberDiscount,
+		PromotionDiscount:    applied.promotionDiscount,
+		CouponDiscount:       couponDiscount,
+		Coupons:              coupons,
+		Total:                total,
+		PreTax:               taxed.preTax,
+		ServiceCharge:        taxed.serviceCharge,
+		VAT:                  taxed.vat,
+		GrandTotal:           taxed.grandTotal,
+		Tax:                  s.tax,
+		CashRounding:         cashTotal.Sub(taxed.grandTotal),
+		CashTotal:            cashTotal,
+		Rounding:             s.rounding,
+		Display:              display,
+		Stock:                stock,
+		NextTiers:            applied.nextTiers,
+		ChosenCombination:    applied.codes,
+		AppliedPromotions:    applied.applied,
+		RejectedCombinations: rejected,
+	}, nil
+}
 
-		unitPrice, ok := priceByCode[code]
-		if !ok {
-			return domain.Money(0), &_foodShopException.MenuItemPriceMissingError{Code: code}
-		}
+// placeOrder gives entry the next order number, reserves its stock and
+// redeems its coupons under that number and adds it to the history. The
+// whole step holds orderMu; stock is put back if the coupons cannot be
+// redeemed, and the number is only used up by an order that was placed.
+func (s *foodShopServiceImpl) placeOrder(entry _orderHistoryModel.OrderHistoryEntry) ([]_foodShopModel.StockLevel, error) {
+	s.orderMu.Lock()
+	defer s.orderMu.Unlock()
 
-		pairCount := qty / pairBundleSize
-		bundleValue := unitPrice.MulInt(pairBundleSize)
-		discountPerBundle := bundleValue.Percent(pairDiscountPercent)
+	orderNo := s.orderNo + 1
 
-		totalDiscount = totalDiscount.Add(discountPerBundle.MulInt(pairCount))
+	stock, err := s.reserveStock(orderNo, entry.Line, entry.CreatedAt)
+	if err != nil {
+		return nil, err
 	}
 
-	return totalDiscount, nil
+	if err := s.redeemCoupons(entry.Coupons, entry.CustomerID, orderNo, entry.CreatedAt); err != nil {
+		if releaseErr := s.releaseStock(orderNo); releaseErr != nil {
+			return nil, errors.Join(err, releaseErr)
+		}
+		return nil, err
+	}
+
+	s.orderNo = orderNo
+	entry.OrderNo = orderNo
+	_ = s.orderHistoryRepository.Add(entry)
+	return stock, nil
 }
 
+func normalizeItemCode(raw string) (_foodShopModel.MenuItemCode, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return "", &_foodShopException.InvalidItemCodeError{}
+	}
+	return _foodShopModel.MenuItemCode(strings.ToUpper(trimmed)), nil
+}
@@ -1,16 +1,31 @@
 package service
 
 import (
+	"time"
+
 	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
 	_orderHistoryModel "github.com/TewApirat/food-shop/pkg/orderHistory/model"
+	_taxInvoiceModel "github.com/TewApirat/food-shop/pkg/taxInvoice/model"
 	
 
 )
 type FoodShopService interface {
 	GetMenuCatalog() ([]_foodShopModel.MenuItem, error)
-	GetPromotions() ([]_foodShopModel.Promotion, error)
+	FilterMenuCatalog(filter _foodShopModel.MenuFilter) ([]_foodShopModel.MenuItem, error)
+	FilterMenuAvailability(filter _foodShopModel.MenuFilter) ([]_foodShopModel.MenuItemStatus, error)
+	AddMenuItem(item _foodShopModel.MenuItem) (_foodShopModel.MenuItem, error)
+	UpdateMenuItem(update _foodShopModel.MenuItemUpdate) (_foodShopModel.MenuItem, error)
+	RetireMenuItem(code string) (_foodShopModel.MenuItem, error)
+	GetPriceHistory(code string) ([]_foodShopModel.PriceVersion, error)
+	GetPriceAt(code string, t time.Time) (_foodShopModel.PriceVersion, error)
+	GetPromotions() ([]_foodShopModel.PromotionStatus, error)
 	QuoteOrder(req _foodShopModel.PurchasingRequest) (_foodShopModel.OrderQuote, error)
 	ListOrderHistory() ([]_orderHistoryModel.OrderHistoryEntry, error)
 	CountOrderHistory() (int, error)
+	CancelOrder(orderNo int) (_orderHistoryModel.OrderHistoryEntry, error)
+	ListStock() ([]_foodShopModel.StockLevel, error)
+	IssueTaxInvoice(req _taxInvoiceModel.IssueRequest) (_taxInvoiceModel.TaxInvoice, error)
+	GetTaxInvoice(number string) (_taxInvoiceModel.TaxInvoice, error)
+	ListTaxInvoices() ([]_taxInvoiceModel.TaxInvoice, error)
 
 }
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	_foodShopException "github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+)
+
+// AddMenuItem adds an item to the menu. The code is upper-cased and the
+// text fields trimmed before the repository validates the item. Price
+// changes listed with the item cannot take effect in the past.
+func (s *foodShopServiceImpl) AddMenuItem(item _foodShopModel.MenuItem) (_foodShopModel.MenuItem, error) {
+	code, err := normalizeItemCode(string(item.Code))
+	if err != nil {
+		return _foodShopModel.MenuItem{}, err
+	}
+	item.Code = code
+	item.Name = strings.TrimSpace(item.Name)
+	item.Description = strings.TrimSpace(item.Description)
+	item.Category = _foodShopModel.MenuCategory(strings.ToUpper(strings.TrimSpace(string(item.Category))))
+
+	now := s.clock.Now()
+	for _, change := range item.PriceChanges {
+		// A missing effectiveFrom is left for the repository to reject.
+		if change.EffectiveFrom.IsZero() {
+			continue
+		}
+		if err := checkNotBackdated(code, change.EffectiveFrom, now); err != nil {
+			return _foodShopModel.MenuItem{}, err
+		}
+	}
+
+	return s.foodShopRepository.AddMenuItem(item)
+}
+
+// UpdateMenuItem renames and/or reprices an item on the menu. A new price
+// takes effect now unless the update schedules it for later; prices
+// cannot be changed in the past.
+func (s *foodShopServiceImpl) UpdateMenuItem(update _foodShopModel.MenuItemUpdate) (_foodShopModel.MenuItem, error) {
+	code, err := normalizeItemCode(string(update.Code))
+	if err != nil {
+		return _foodShopModel.MenuItem{}, err
+	}
+	update.Code = code
+
+	now := s.clock.Now()
+	if update.EffectiveFrom.IsZero() {
+		update.EffectiveFrom = now
+	}
+	if update.Price != nil {
+		if err := checkNotBackdated(code, update.EffectiveFrom, now); err != nil {
+			return _foodShopModel.MenuItem{}, err
+		}
+	}
+	if update.Name != nil {
+		name := strings.TrimSpace(*update.Name)
+		update.Name = &name
+	}
+
+	return s.foodShopRepository.UpdateMenuItem(update)
+}
+
+// checkNotBackdated rejects a price that would take effect before now,
+// which would change what quotes already made should have cost.
+func checkNotBackdated(code _foodShopModel.MenuItemCode, effectiveFrom, now time.Time) error {
+	if effectiveFrom.Before(now) {
+		return &_foodShopException.InvalidMenuItemError{
+			Code:   string(code),
+			Reason: "effectiveFrom is in the past",
+		}
+	}
+	return nil
+}
+
+// RetireMenuItem takes an item off the menu now. Orders in the history
+// keep their lines for it.
+func (s *foodShopServiceImpl) RetireMenuItem(rawCode string) (_foodShopModel.MenuItem, error) {
+	code, err := normalizeItemCode(rawCode)
+	if err != nil {
+		return _foodShopModel.MenuItem{}, err
+	}
+	return s.foodShopRepository.RetireMenuItem(code, s.clock.Now())
+}
+
+// GetPriceHistory lists every price version of an item, oldest first,
+// including versions scheduled for later. Retired items keep theirs.
+func (s *foodShopServiceImpl) GetPriceHistory(rawCode string) ([]_foodShopModel.PriceVersion, error) {
+	code, err := normalizeItemCode(rawCode)
+	if err != nil {
+		return nil, err
+	}
+	item, err := s.foodShopRepository.FindMenuItemByCode(code)
+	if err != nil {
+		return nil, err
+	}
+	return item.Prices(), nil
+}
+
+// GetPriceAt returns the price version of an item in effect at t.
+func (s *foodShopServiceImpl) GetPriceAt(rawCode string, t time.Time) (_foodShopModel.PriceVersion, error) {
+	code, err := normalizeItemCode(rawCode)
+	if err != nil {
+		return _foodShopModel.PriceVersion{}, err
+	}
+	item, err := s.foodShopRepository.FindMenuItemByCode(code)
+	if err != nil {
+		return _foodShopModel.PriceVersion{}, err
+	}
+	return item.PriceAt(t), nil
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+)
+
+// allocateLineDiscounts gives every line its share of each promotion and
+// coupon, in the order they applied, and sets the line NetTotal.
+// Promotions that name the units they covered are spread over those units,
+// first line of a code first, or over just the units they gave away when
+// there are any, so a free unit's line is the one that comes out free.
+// Bill-level promotions and coupons are spread over what each line still
+// owes. A share never takes a line below zero, so the line NetTotals
+// always add up to the quote Total. Shares are rounded with mode.
+// It fails only when a weight overflows.
+func allocateLineDiscounts(
+	lines []_foodShopModel.OrderLine,
+	promotions []_foodShopModel.AppliedPromotion,
+	coupons []_foodShopModel.AppliedCoupon,
+	mode domain.RoundingMode,
+) error {
+	net := make([]domain.Money, len(lines))
+	for i := range lines {
+		net[i] = lines[i].LineTotal
+		lines[i].Discounts = nil
+	}
+
+	for _, promo := range promotions {
+		weights := make([]domain.Money, len(lines))
+		if len(promo.Items) == 0 {
+			copy(weights, net)
+		} else {
+			covered := promo.Items
+			if len(promo.FreeUnits) > 0 {
+				covered = promo.FreeUnits
+			}
+			units := make(map[_foodShopModel.MenuItemCode]int, len(covered))
+			for code, qty := range covered {
+				units[code] = qty
+			}
+			for i, line := range lines {
+				take := min(units[line.Code], line.Qty)
+				units[line.Code] -= take
+				weight, err := line.UnitPrice.MulIntChecked(take)
+				if err != nil {
+					return amountError("promotion "+promo.Code, err)
+				}
+				weights[i] = weight
+			}
+		}
+		allocateDiscount(lines, net, promo.Code, promo.Discount, weights, mode)
+	}
+
+	for _, coupon := range coupons {
+		eligible := make(map[_foodShopModel.MenuItemCode]bool, len(coupon.EligibleCodes))
+		for _, code := range coupon.EligibleCodes {
+			eligible[code] = true
+		}
+
+		weights := make([]domain.Money, len(lines))
+		for i, line := range lines {
+			if len(eligible) == 0 || eligible[line.Code] {
+				weights[i] = net[i]
+			}
+		}
+		allocateDiscount(lines, net, coupon.Code, coupon.Discount, weights, mode)
+	}
+
+	for i := range lines {
+		lines[i].NetTotal = net[i]
+	}
+	return nil
+}
+
+// allocateDiscount splits amount over the lines by weight. Any share above
+// what a line still owes moves to the next lines that have room.
+func allocateDiscount(
+	lines []_foodShopModel.OrderLine,
+	net []domain.Money,
+	code string,
+	amount domain.Money,
+	weights []domain.Money,
+	mode domain.RoundingMode,
+) {
+	if amount <= 0 {
+		return
+	}
+
+	shares := amount.AllocateRounded(weights, mode)
+
+	var excess domain.Money
+	for i := range shares {
+		if shares[i] > net[i] {
+			excess = excess.Add(shares[i].Sub(net[i]))
+			shares[i] = net[i]
+		}
+	}
+	for i := range shares {
+		if excess == 0 {
+			break
+		}
+		take := min(net[i].Sub(shares[i]), excess)
+		shares[i] = shares[i].Add(take)
+		excess = excess.Sub(take)
+	}
+
+	for i, share := range shares {
+		if share == 0 {
+			continue
+		}
+		lines[i].Discounts = append(lines[i].Discounts, _foodShopModel.LineDiscount{Code: code, Amount: share})
+		net[i] = net[i].Sub(share)
+	}
+}
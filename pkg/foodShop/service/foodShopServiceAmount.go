@@ -0,0 +1,34 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopException "github.com/TewApirat/food-shop/pkg/foodShop/exception"
+)
+
+// DefaultMaxOrderValue is the largest subtotal a quote accepts unless
+// WithMaxOrderValue says otherwise: 1,000,000 THB.
+const DefaultMaxOrderValue = domain.Money(1_000_000 * 100)
+
+// checkOrderValue fails when amount, reached at step, is over the maximum
+// order value. A maximum of zero or less only guards against overflow.
+func (s *foodShopServiceImpl) checkOrderValue(step string, amount domain.Money) error {
+	if s.maxOrderValue > 0 && amount > s.maxOrderValue {
+		return &_foodShopException.AmountOverflowError{Step: step, Limit: s.maxOrderValue}
+	}
+	return nil
+}
+
+// amountError reports domain.ErrAmountOverflow as an AmountOverflowError
+// at step. Any other error, including nil, is returned as is.
+func amountError(step string, err error) error {
+	if errors.Is(err, domain.ErrAmountOverflow) {
+		var overflow *_foodShopException.AmountOverflowError
+		if errors.As(err, &overflow) {
+			return err
+		}
+		return &_foodShopException.AmountOverflowError{Step: step}
+	}
+	return err
+}
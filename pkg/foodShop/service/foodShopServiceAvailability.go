@@ -0,0 +1,66 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	_foodShopException "github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+)
+
+// FilterMenuAvailability lists the menu items matching filter with their
+// current price and any price change to come, flagging the ones that
+// cannot be ordered right now and when they next can.
+func (s *foodShopServiceImpl) FilterMenuAvailability(filter _foodShopModel.MenuFilter) ([]_foodShopModel.MenuItemStatus, error) {
+	items, err := s.foodShopRepository.ListMenuItemsBy(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	statuses := make([]_foodShopModel.MenuItemStatus, 0, len(items))
+	for _, item := range items {
+		status := _foodShopModel.MenuItemStatus{MenuItem: item, CurrentPrice: item.PriceAt(now)}
+		if next, ok := item.NextPriceAfter(now); ok {
+			status.NextPrice = &next
+		}
+		status.Available, err = item.AvailableAt(now)
+		if err != nil {
+			return nil, fmt.Errorf("menu item %s availability: %w", item.Code, err)
+		}
+		if !status.Available {
+			status.NextAvailable, _, err = item.NextAvailable(now)
+			if err != nil {
+				return nil, fmt.Errorf("menu item %s availability: %w", item.Code, err)
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// checkAvailable rejects a retired item and one that cannot be ordered at
+// now, saying when it next can.
+func checkAvailable(item _foodShopModel.MenuItem, now time.Time) error {
+	if item.Retired() {
+		return &_foodShopException.MenuItemRetiredError{Code: string(item.Code), RetiredAt: item.RetiredAt}
+	}
+
+	available, err := item.AvailableAt(now)
+	if err != nil {
+		return fmt.Errorf("menu item %s availability: %w", item.Code, err)
+	}
+	if available {
+		return nil
+	}
+
+	next, _, err := item.NextAvailable(now)
+	if err != nil {
+		return fmt.Errorf("menu item %s availability: %w", item.Code, err)
+	}
+	return &_foodShopException.MenuItemUnavailableError{
+		Code:          string(item.Code),
+		Availability:  item.Availability.String(),
+		NextAvailable: next,
+	}
+}
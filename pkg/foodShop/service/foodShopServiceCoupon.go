@@ -0,0 +1,173 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	_couponModel "github.com/TewApirat/food-shop/pkg/coupon/model"
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopException "github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+)
+
+// applyCoupons validates the requested coupons and takes them off the
+// amount left after promotions, in request order. Each coupon works on
+// what its lines still owe after the promotions and the coupons before
+// it. Nothing is redeemed here; see redeemCoupons.
+func (s *foodShopServiceImpl) applyCoupons(
+	req _foodShopModel.PurchasingRequest,
+	lines []_foodShopModel.OrderLine,
+	promotions []_foodShopModel.AppliedPromotion,
+	running domain.Money,
+	now time.Time,
+) ([]_foodShopModel.AppliedCoupon, error) {
+	codes, err := normalizeCouponCodes(req.Coupons)
+	if err != nil {
+		return nil, err
+	}
+	if len(codes) == 0 {
+		return nil, nil
+	}
+	if s.couponRepository == nil {
+		return nil, &_foodShopException.InvalidCouponError{Code: codes[0]}
+	}
+
+	applied := make([]_foodShopModel.AppliedCoupon, 0, len(codes))
+	for _, code := range codes {
+		coupon, err := s.couponRepository.FindByCode(code)
+		if err != nil {
+			return nil, fmt.Errorf("find coupon %s: %w", code, err)
+		}
+
+		if err := s.checkCouponRedeemable(coupon, req.CustomerID, now); err != nil {
+			return nil, err
+		}
+
+		if running < coupon.MinSpend {
+			return nil, &_foodShopException.CouponNotApplicableError{
+				Code:   coupon.Code,
+				Reason: fmt.Sprintf("minimum spend is %s", coupon.MinSpend.String()),
+			}
+		}
+
+		if err := allocateLineDiscounts(lines, promotions, applied, s.rounding.Allocation); err != nil {
+			return nil, err
+		}
+		base, found := couponBase(coupon, lines, running)
+		if !found {
+			return nil, &_foodShopException.CouponNotApplicableError{Code: coupon.Code, Reason: "no eligible items in order"}
+		}
+		if base <= 0 {
+			return nil, &_foodShopException.CouponNotApplicableError{Code: coupon.Code, Reason: "eligible items have nothing left to pay"}
+		}
+
+		var discount domain.Money
+		switch coupon.Type {
+		case _couponModel.CouponTypePercent:
+			var err error
+			discount, err = base.MulRate(coupon.Rate, s.rounding.Coupon)
+			if err != nil {
+				return nil, err
+			}
+		case _couponModel.CouponTypeFixed:
+			discount = coupon.Amount
+			if discount > base {
+				discount = base
+			}
+		default:
+			return nil, &_foodShopException.InvalidCouponError{Code: coupon.Code}
+		}
+
+		running = running.Sub(discount)
+		applied = append(applied, _foodShopModel.AppliedCoupon{
+			Code:          coupon.Code,
+			Discount:      discount,
+			EligibleCodes: append([]_foodShopModel.MenuItemCode(nil), coupon.EligibleCodes...),
+		})
+	}
+
+	return applied, nil
+}
+
+func (s *foodShopServiceImpl) checkCouponRedeemable(coupon _couponModel.Coupon, customerID string, now time.Time) error {
+	if !coupon.ExpiresAt.IsZero() && !now.Before(coupon.ExpiresAt) {
+		return &_foodShopException.ExpiredCouponError{Code: coupon.Code, ExpiredAt: coupon.ExpiresAt}
+	}
+	if coupon.MaxRedemptionsPerCustomer > 0 && customerID == "" {
+		return &_foodShopException.CouponNotApplicableError{Code: coupon.Code, Reason: "customerId is required"}
+	}
+
+	total, byCustomer, err := s.couponRepository.CountRedemptions(coupon.Code, customerID)
+	if err != nil {
+		return fmt.Errorf("count coupon redemptions %s: %w", coupon.Code, err)
+	}
+	if coupon.MaxRedemptions > 0 && total >= coupon.MaxRedemptions {
+		return &_foodShopException.CouponExhaustedError{Code: coupon.Code, Limit: coupon.MaxRedemptions}
+	}
+	if coupon.MaxRedemptionsPerCustomer > 0 && byCustomer >= coupon.MaxRedemptionsPerCustomer {
+		return &_foodShopException.CouponExhaustedError{Code: coupon.Code, Limit: coupon.MaxRedemptionsPerCustomer, PerCustomer: true}
+	}
+	return nil
+}
+
+func (s *foodShopServiceImpl) redeemCoupons(
+	applied []_foodShopModel.AppliedCoupon,
+	customerID string,
+	orderNo int,
+	now time.Time,
+) error {
+	if len(applied) == 0 {
+		return nil
+	}
+
+	redemptions := make([]_couponModel.Redemption, 0, len(applied))
+	for _, coupon := range applied {
+		redemptions = append(redemptions, _couponModel.Redemption{
+			Code:       coupon.Code,
+			CustomerID: customerID,
+			OrderNo:    orderNo,
+			RedeemedAt: now,
+		})
+	}
+	return s.couponRepository.Redeem(redemptions)
+}
+
+// couponBase is the part of the bill a coupon may discount: what its
+// eligible lines still owe, or the whole remaining bill if it has none.
+// found is false when the order has no eligible line at all.
+func couponBase(coupon _couponModel.Coupon, lines []_foodShopModel.OrderLine, running domain.Money) (base domain.Money, found bool) {
+	if len(coupon.EligibleCodes) == 0 {
+		return running, true
+	}
+
+	eligible := make(map[_foodShopModel.MenuItemCode]bool, len(coupon.EligibleCodes))
+	for _, code := range coupon.EligibleCodes {
+		eligible[code] = true
+	}
+
+	for _, line := range lines {
+		if eligible[line.Code] {
+			base = base.Add(line.NetTotal)
+			found = true
+		}
+	}
+	return base, found
+}
+
+func normalizeCouponCodes(raw []string) ([]string, error) {
+	codes := make([]string, 0, len(raw))
+	seen := make(map[string]bool, len(raw))
+	for _, r := range raw {
+		code := strings.ToUpper(strings.TrimSpace(r))
+		if code == "" {
+			return nil, &_foodShopException.InvalidCouponError{}
+		}
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
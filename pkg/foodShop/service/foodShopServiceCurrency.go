@@ -0,0 +1,48 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopException "github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+)
+
+// convertForDisplay converts total into the requested display currency.
+// No currency means no conversion; THB is shown at a rate of 1. Display
+// amounts round half-up since they are never charged.
+func (s *foodShopServiceImpl) convertForDisplay(code string, total domain.Money) (*_foodShopModel.DisplayTotal, error) {
+	if code == "" {
+		return nil, nil
+	}
+
+	currency, err := domain.ParseCurrency(code)
+	if err != nil {
+		return nil, &_foodShopException.UnsupportedCurrencyError{Code: code}
+	}
+
+	if currency == domain.CurrencyTHB {
+		return &_foodShopModel.DisplayTotal{
+			Total: domain.Amount{Minor: int64(total), Currency: currency},
+			Rate:  domain.ExchangeRate(1_000_000),
+		}, nil
+	}
+
+	if s.exchangeRateRepository == nil {
+		return nil, &_foodShopException.ExchangeRateNotFoundError{Currency: currency}
+	}
+	rate, err := s.exchangeRateRepository.FindRate(currency)
+	if err != nil {
+		return nil, fmt.Errorf("find exchange rate %s: %w", currency, err)
+	}
+
+	converted, err := total.Convert(currency, rate.THBPerUnit, domain.RoundHalfUp)
+	if err != nil {
+		return nil, amountError("display total", err)
+	}
+	return &_foodShopModel.DisplayTotal{
+		Total:         converted,
+		Rate:          rate.THBPerUnit,
+		RateUpdatedAt: rate.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	_foodShopException "github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_inventoryModel "github.com/TewApirat/food-shop/pkg/inventory/model"
+	_orderHistoryModel "github.com/TewApirat/food-shop/pkg/orderHistory/model"
+)
+
+// ListStock lists what is left of every stock-tracked menu item. Without
+// an inventory every item is unlimited and the list is empty.
+func (s *foodShopServiceImpl) ListStock() ([]_foodShopModel.StockLevel, error) {
+	if s.inventoryRepository == nil {
+		return nil, nil
+	}
+	return s.inventoryRepository.List()
+}
+
+// CancelOrder cancels an order in the history and puts back the stock it
+// reserved. An order with a tax invoice cannot be cancelled. The stock is
+// released before the order is marked, and reserved again if marking it
+// fails, so a failed cancel can be retried. Coupons redeemed by the order
+// stay used. The whole step holds orderMu, so no invoice can be issued
+// between the check and the cancel.
+func (s *foodShopServiceImpl) CancelOrder(orderNo int) (_orderHistoryModel.OrderHistoryEntry, error) {
+	s.orderMu.Lock()
+	defer s.orderMu.Unlock()
+
+	entry, err := s.orderHistoryRepository.FindByOrderNo(orderNo)
+	if err != nil {
+		return _orderHistoryModel.OrderHistoryEntry{}, err
+	}
+	if entry.Cancelled() {
+		return _orderHistoryModel.OrderHistoryEntry{}, &_foodShopException.OrderAlreadyCancelledError{
+			OrderNo:     orderNo,
+			CancelledAt: entry.CancelledAt,
+		}
+	}
+	if err := s.checkNoTaxInvoice(orderNo); err != nil {
+		return _orderHistoryModel.OrderHistoryEntry{}, err
+	}
+
+	var released _inventoryModel.Reservation
+	if s.inventoryRepository != nil {
+		if released, err = s.inventoryRepository.Release(orderNo); err != nil {
+			return _orderHistoryModel.OrderHistoryEntry{}, fmt.Errorf("release stock of order #%d: %w", orderNo, err)
+		}
+	}
+
+	entry, err = s.orderHistoryRepository.Cancel(orderNo, s.clock.Now())
+	if err != nil {
+		if len(released.Items) > 0 {
+			if _, reserveErr := s.inventoryRepository.Reserve(released); reserveErr != nil {
+				return _orderHistoryModel.OrderHistoryEntry{}, errors.Join(err, fmt.Errorf("reserve stock of order #%d again: %w", orderNo, reserveErr))
+			}
+		}
+		return _orderHistoryModel.OrderHistoryEntry{}, err
+	}
+	return entry, nil
+}
+
+// checkNoTaxInvoice rejects cancelling an order a tax invoice was issued
+// for; the invoice would stay valid for a sale that did not happen.
+func (s *foodShopServiceImpl) checkNoTaxInvoice(orderNo int) error {
+	if s.taxInvoiceRepository == nil {
+		return nil
+	}
+
+	invoice, err := s.taxInvoiceRepository.FindByOrderNo(orderNo)
+	if err == nil {
+		return &_foodShopException.OrderNotCancellableError{
+			OrderNo: orderNo,
+			Reason:  fmt.Sprintf("tax invoice %s was issued for it", invoice.Number),
+		}
+	}
+	var notFound *_foodShopException.TaxInvoiceNotFoundError
+	if !errors.As(err, &notFound) {
+		return fmt.Errorf("find tax invoice of order #%d: %w", orderNo, err)
+	}
+	return nil
+}
+
+// stockLeft returns how many units of code are left and whether code is
+// stock-tracked at all.
+func (s *foodShopServiceImpl) stockLeft(code _foodShopModel.MenuItemCode) (int, bool, error) {
+	if s.inventoryRepository == nil {
+		return 0, false, nil
+	}
+
+	levels, err := s.inventoryRepository.List()
+	if err != nil {
+		return 0, false, fmt.Errorf("list stock: %w", err)
+	}
+	for _, level := range levels {
+		if level.Code == code {
+			return level.Remaining, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// reserveStock holds the units on the order's lines, reward items added
+// by promotions included, all or nothing, and returns what is left of the
+// stock-tracked items on it.
+func (s *foodShopServiceImpl) reserveStock(
+	orderNo int,
+	lines []_foodShopModel.OrderLine,
+	now time.Time,
+) ([]_foodShopModel.StockLevel, error) {
+	if s.inventoryRepository == nil {
+		return nil, nil
+	}
+
+	qtyByCode := make(map[_foodShopModel.MenuItemCode]int, len(lines))
+	for _, line := range lines {
+		qtyByCode[line.Code] += line.Qty
+	}
+	return s.inventoryRepository.Reserve(_inventoryModel.Reservation{
+		OrderNo:    orderNo,
+		Items:      qtyByCode,
+		ReservedAt: now,
+	})
+}
+
+func (s *foodShopServiceImpl) releaseStock(orderNo int) error {
+	if s.inventoryRepository == nil {
+		return nil
+	}
+
+	if _, err := s.inventoryRepository.Release(orderNo); err != nil {
+		return fmt.Errorf("release stock of order #%d: %w", orderNo, err)
+	}
+	return nil
+}
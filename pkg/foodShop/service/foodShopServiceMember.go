@@ -0,0 +1,42 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	_foodShopException "github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	_memberModel "github.com/TewApirat/food-shop/pkg/member/model"
+)
+
+// resolveMember looks up the member on the request. Walk-in customers (no
+// member ID) resolve to the zero Member. Unknown, expired and suspended
+// members are errors so a bad card never silently loses its discount.
+func (s *foodShopServiceImpl) resolveMember(rawID string, now time.Time) (_memberModel.Member, error) {
+	id := strings.ToUpper(strings.TrimSpace(rawID))
+	if id == "" {
+		return _memberModel.Member{}, nil
+	}
+
+	if s.memberRepository == nil {
+		return _memberModel.Member{}, &_foodShopException.UnknownMemberError{ID: id}
+	}
+
+	member, err := s.memberRepository.FindMember(id)
+	if err != nil {
+		var unknown *_foodShopException.UnknownMemberError
+		if errors.As(err, &unknown) {
+			return _memberModel.Member{}, err
+		}
+		return _memberModel.Member{}, fmt.Errorf("find member %s: %w", id, err)
+	}
+
+	if member.Suspended {
+		return _memberModel.Member{}, &_foodShopException.SuspendedMemberError{ID: member.ID}
+	}
+	if !member.ExpiresAt.IsZero() && !now.Before(member.ExpiresAt) {
+		return _memberModel.Member{}, &_foodShopException.ExpiredMemberError{ID: member.ID, ExpiredAt: member.ExpiresAt}
+	}
+	return member, nil
+}
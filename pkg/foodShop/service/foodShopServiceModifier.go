@@ -0,0 +1,76 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopException "github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+)
+
+// selectOptions checks the option codes chosen for item against its
+// modifier groups and returns the selected options, in menu order, with
+// the sum of their price deltas. Every code must name an option of the
+// item, no option may be chosen twice and every group must get between
+// its minimum and maximum number of picks.
+func selectOptions(item _foodShopModel.MenuItem, rawCodes []string) ([]_foodShopModel.SelectedOption, domain.Money, error) {
+	invalid := func(group, option, reason string) error {
+		return &_foodShopException.InvalidModifierError{Code: string(item.Code), Group: group, Option: option, Reason: reason}
+	}
+
+	chosen := make(map[string]bool, len(rawCodes))
+	for _, raw := range rawCodes {
+		code := strings.ToUpper(strings.TrimSpace(raw))
+		if chosen[code] {
+			return nil, 0, invalid("", code, fmt.Sprintf("option %s chosen twice", code))
+		}
+		if !hasOption(item, code) {
+			return nil, 0, invalid("", code, fmt.Sprintf("unknown option %q", raw))
+		}
+		chosen[code] = true
+	}
+
+	var selected []_foodShopModel.SelectedOption
+	var delta domain.Money
+	for _, group := range item.Modifiers {
+		picks := 0
+		for _, option := range group.Options {
+			if !chosen[option.Code] {
+				continue
+			}
+			picks++
+
+			var err error
+			delta, err = delta.AddChecked(option.PriceDelta)
+			if err != nil {
+				return nil, 0, amountError("options of "+string(item.Code), err)
+			}
+			selected = append(selected, _foodShopModel.SelectedOption{
+				Group:      group.Code,
+				Code:       option.Code,
+				Name:       option.Name,
+				PriceDelta: option.PriceDelta,
+			})
+		}
+
+		if picks < group.MinSelections {
+			return nil, 0, invalid(group.Code, "", fmt.Sprintf("choose at least %d from %s", group.MinSelections, group.Name))
+		}
+		if group.MaxSelections > 0 && picks > group.MaxSelections {
+			return nil, 0, invalid(group.Code, "", fmt.Sprintf("choose at most %d from %s", group.MaxSelections, group.Name))
+		}
+	}
+	return selected, delta, nil
+}
+
+func hasOption(item _foodShopModel.MenuItem, code string) bool {
+	for _, group := range item.Modifiers {
+		for _, option := range group.Options {
+			if option.Code == code {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	_couponRepository "github.com/TewApirat/food-shop/pkg/coupon/repository"
+	_exchangeRateRepository "github.com/TewApirat/food-shop/pkg/exchangeRate/repository"
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopPromotion "github.com/TewApirat/food-shop/pkg/foodShop/promotion"
+	_inventoryRepository "github.com/TewApirat/food-shop/pkg/inventory/repository"
+	_memberRepository "github.com/TewApirat/food-shop/pkg/member/repository"
+	_taxInvoiceModel "github.com/TewApirat/food-shop/pkg/taxInvoice/model"
+	_taxInvoiceRepository "github.com/TewApirat/food-shop/pkg/taxInvoice/repository"
+)
+
+// Option customises a foodShopServiceImpl built by NewFoodShopServiceImpl.
+type Option func(s *foodShopServiceImpl)
+
+// WithPromotionRules pins the promotion pipeline instead of building it
+// from FoodShopRepository.ListPromotions on every quote. Rules are
+// evaluated in the order given.
+func WithPromotionRules(rules ..._foodShopPromotion.PromotionRule) Option {
+	return func(s *foodShopServiceImpl) {
+		pipeline := _foodShopPromotion.NewPipeline(rules...)
+		s.promotionPipeline = &pipeline
+	}
+}
+
+// WithClock sets the clock quotes, promotion windows and history
+// timestamps are evaluated against. Defaults to the system clock.
+func WithClock(clock domain.Clock) Option {
+	return func(s *foodShopServiceImpl) {
+		s.clock = clock
+	}
+}
+
+// WithCouponRepository enables coupon codes on PurchasingRequest. Without
+// one every coupon code is rejected as invalid.
+func WithCouponRepository(couponRepository _couponRepository.CouponRepository) Option {
+	return func(s *foodShopServiceImpl) {
+		s.couponRepository = couponRepository
+	}
+}
+
+// WithMemberRepository enables member IDs on PurchasingRequest. Without
+// one every member ID is rejected as unknown.
+func WithMemberRepository(memberRepository _memberRepository.MemberRepository) Option {
+	return func(s *foodShopServiceImpl) {
+		s.memberRepository = memberRepository
+	}
+}
+
+// WithExchangeRateRepository enables DisplayCurrency on PurchasingRequest.
+// Without one only THB can be displayed.
+func WithExchangeRateRepository(exchangeRateRepository _exchangeRateRepository.ExchangeRateRepository) Option {
+	return func(s *foodShopServiceImpl) {
+		s.exchangeRateRepository = exchangeRateRepository
+	}
+}
+
+// WithTaxInvoiceRepository enables full tax invoices for orders in the
+// history. Without one IssueTaxInvoice always fails.
+func WithTaxInvoiceRepository(taxInvoiceRepository _taxInvoiceRepository.TaxInvoiceRepository) Option {
+	return func(s *foodShopServiceImpl) {
+		s.taxInvoiceRepository = taxInvoiceRepository
+	}
+}
+
+// WithInventoryRepository enables stock tracking: quotes reserve the
+// stock of what they order and cancelling an order puts it back. Without
+// one every menu item is unlimited.
+func WithInventoryRepository(inventoryRepository _inventoryRepository.InventoryRepository) Option {
+	return func(s *foodShopServiceImpl) {
+		s.inventoryRepository = inventoryRepository
+	}
+}
+
+// WithSeller sets the shop named as seller on tax invoices. Defaults to
+// taxInvoice/model.DefaultSeller.
+func WithSeller(seller _taxInvoiceModel.Party) Option {
+	return func(s *foodShopServiceImpl) {
+		s.seller = seller
+	}
+}
+
+// WithMaxOrderValue sets the largest subtotal a quote accepts; larger
+// orders fail with an AmountOverflowError. Zero or less removes the limit.
+// Defaults to DefaultMaxOrderValue.
+func WithMaxOrderValue(limit domain.Money) Option {
+	return func(s *foodShopServiceImpl) {
+		s.maxOrderValue = limit
+	}
+}
+
+// WithTaxPolicy sets the service charge and VAT added to every quote.
+// Defaults to the zero TaxPolicy, which charges nothing.
+func WithTaxPolicy(policy _foodShopModel.TaxPolicy) Option {
+	return func(s *foodShopServiceImpl) {
+		s.tax = policy
+	}
+}
+
+// WithRoundingStrategy sets how each pricing step rounds. Defaults to
+// model.DefaultRoundingStrategy.
+func WithRoundingStrategy(strategy _foodShopModel.RoundingStrategy) Option {
+	return func(s *foodShopServiceImpl) {
+		s.rounding = strategy
+	}
+}
@@ -0,0 +1,301 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopPromotion "github.com/TewApirat/food-shop/pkg/foodShop/promotion"
+)
+
+// promotionRun is the order after one combination of promotions: the lines
+// with any reward items added, the promotions that took something off and
+// the discounts summed into the quote's buckets. total is what is left
+// after the promotions, before coupons.
+type promotionRun struct {
+	codes             []string
+	lines             []_foodShopModel.OrderLine
+	applied           []_foodShopModel.AppliedPromotion
+	subtotal          domain.Money
+	pairDiscount      domain.Money
+	memberDiscount    domain.Money
+	promotionDiscount domain.Money
+	nextTiers         []_foodShopModel.SpendTierHint
+	total             domain.Money
+}
+
+// applyBestPromotions runs every combination of active promotions the
+// stacking policy allows and returns the one that leaves the customer the
+// lowest total, together with the combinations it beat. On a tie the
+// earlier combination wins. A pipeline pinned with WithPromotionRules is
+// run as is.
+func (s *foodShopServiceImpl) applyBestPromotions(
+	ctx _foodShopPromotion.Context,
+	lines []_foodShopModel.OrderLine,
+	now time.Time,
+) (promotionRun, []_foodShopModel.PromotionCombination, error) {
+	if s.promotionPipeline != nil {
+		run, err := s.runPromotions(*s.promotionPipeline, ctx, lines, now)
+		if err != nil {
+			return promotionRun{}, nil, err
+		}
+		for _, rule := range s.promotionPipeline.Rules() {
+			run.codes = append(run.codes, rule.Code())
+		}
+		return run, nil, nil
+	}
+
+	promotions, err := s.loadActivePromotions(now)
+	if err != nil {
+		return promotionRun{}, nil, err
+	}
+
+	combinations, err := _foodShopPromotion.Combinations(promotions)
+	if err != nil {
+		return promotionRun{}, nil, err
+	}
+
+	runs := make([]promotionRun, 0, len(combinations))
+	best := 0
+	for _, combination := range combinations {
+		pipeline, err := _foodShopPromotion.BuildPipeline(combination)
+		if err != nil {
+			return promotionRun{}, nil, err
+		}
+
+		run, err := s.runPromotions(pipeline, ctx, lines, now)
+		if err != nil {
+			return promotionRun{}, nil, err
+		}
+		run.codes = promotionCodesInOrder(combination)
+
+		runs = append(runs, run)
+		if run.total < runs[best].total {
+			best = len(runs) - 1
+		}
+	}
+
+	var rejected []_foodShopModel.PromotionCombination
+	for i, run := range runs {
+		if i == best {
+			continue
+		}
+		rejected = append(rejected, _foodShopModel.PromotionCombination{Codes: run.codes, Total: run.total})
+	}
+	return runs[best], rejected, nil
+}
+
+// loadActivePromotions lists the promotions whose schedule is open at now.
+func (s *foodShopServiceImpl) loadActivePromotions(now time.Time) ([]_foodShopModel.Promotion, error) {
+	promotions, err := s.foodShopRepository.ListPromotions()
+	if err != nil {
+		return nil, fmt.Errorf("list promotions: %w", err)
+	}
+
+	active := make([]_foodShopModel.Promotion, 0, len(promotions))
+	for _, promo := range promotions {
+		ok, err := promo.ActiveAt(now)
+		if err != nil {
+			return nil, fmt.Errorf("promotion %s schedule: %w", promo.Code, err)
+		}
+		if ok {
+			active = append(active, promo)
+		}
+	}
+	return active, nil
+}
+
+// runPromotions prices the order under one pipeline. ctx and lines are not
+// modified, so the same order can be run under several pipelines.
+func (s *foodShopServiceImpl) runPromotions(
+	pipeline _foodShopPromotion.Pipeline,
+	ctx _foodShopPromotion.Context,
+	lines []_foodShopModel.OrderLine,
+	now time.Time,
+) (promotionRun, error) {
+	ctx.QtyByCode = copyQty(ctx.QtyByCode)
+	ctx.PriceByCode = copyPrices(ctx.PriceByCode)
+	lines = append([]_foodShopModel.OrderLine(nil), lines...)
+
+	lines, err := s.addRewardItems(pipeline, ctx, lines, now)
+	if err != nil {
+		return promotionRun{}, err
+	}
+
+	run := promotionRun{}
+	for _, line := range lines {
+		run.subtotal, err = run.subtotal.AddChecked(line.LineTotal)
+		if err != nil {
+			return promotionRun{}, amountError("subtotal", err)
+		}
+	}
+	if err := s.checkOrderValue("subtotal", run.subtotal); err != nil {
+		return promotionRun{}, err
+	}
+	ctx.Subtotal = run.subtotal
+
+	results, err := pipeline.Apply(ctx)
+	if err != nil {
+		return promotionRun{}, amountError("promotions", err)
+	}
+
+	for _, result := range results {
+		if result.NextTier != nil {
+			run.nextTiers = append(run.nextTiers, *result.NextTier)
+		}
+		if result.Discount > 0 {
+			run.applied = append(run.applied, _foodShopModel.AppliedPromotion{
+				Code:        result.Code,
+				Items:       result.Items,
+				FreeUnits:   result.FreeUnits,
+				Base:        result.Base,
+				Rate:        result.Rate,
+				Discount:    result.Discount,
+				Explanation: result.Explanation,
+			})
+		}
+		bucket := &run.promotionDiscount
+		switch result.Kind {
+		case _foodShopPromotion.KindPair:
+			bucket = &run.pairDiscount
+		case _foodShopPromotion.KindMember:
+			bucket = &run.memberDiscount
+		}
+		if *bucket, err = bucket.AddChecked(result.Discount); err != nil {
+			return promotionRun{}, amountError("promotion "+result.Code, err)
+		}
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].Code < lines[j].Code
+	})
+	markFreeUnits(lines, results)
+
+	run.lines = lines
+	run.total = run.subtotal
+	for _, discount := range []domain.Money{run.pairDiscount, run.memberDiscount, run.promotionDiscount} {
+		if run.total, err = run.total.SubChecked(discount); err != nil {
+			return promotionRun{}, amountError("total", err)
+		}
+	}
+	return run, nil
+}
+
+// promotionCodesInOrder lists the codes of promotions in the order the
+// pipeline applies them.
+func promotionCodesInOrder(promotions []_foodShopModel.Promotion) []string {
+	ordered := make([]_foodShopModel.Promotion, len(promotions))
+	copy(ordered, promotions)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Priority < ordered[j].Priority
+	})
+
+	codes := make([]string, len(ordered))
+	for i, promo := range ordered {
+		codes[i] = promo.Code
+	}
+	return codes
+}
+
+func copyQty(qtyByCode map[_foodShopModel.MenuItemCode]int) map[_foodShopModel.MenuItemCode]int {
+	owned := make(map[_foodShopModel.MenuItemCode]int, len(qtyByCode))
+	for code, qty := range qtyByCode {
+		owned[code] = qty
+	}
+	return owned
+}
+
+func copyPrices(priceByCode map[_foodShopModel.MenuItemCode]domain.Money) map[_foodShopModel.MenuItemCode]domain.Money {
+	owned := make(map[_foodShopModel.MenuItemCode]domain.Money, len(priceByCode))
+	for code, price := range priceByCode {
+		owned[code] = price
+	}
+	return owned
+}
+
+// addRewardItems puts the reward units promised by RewardAdder rules into
+// the order when the customer did not add them, at the menu price in
+// effect at now. A reward that could not be ordered at now, because it is
+// retired or outside its availability, is left out, and one with limited
+// stock is added only up to the units the customer's own lines leave. The
+// context maps are updated in place so the pipeline sees the added units.
+func (s *foodShopServiceImpl) addRewardItems(
+	pipeline _foodShopPromotion.Pipeline,
+	ctx _foodShopPromotion.Context,
+	lines []_foodShopModel.OrderLine,
+	now time.Time,
+) ([]_foodShopModel.OrderLine, error) {
+	for _, rule := range pipeline.Rules() {
+		adder, ok := rule.(_foodShopPromotion.RewardAdder)
+		if !ok {
+			continue
+		}
+
+		for code, want := range adder.RewardItems(ctx) {
+			missing := want - ctx.QtyByCode[code]
+			if missing < 1 {
+				continue
+			}
+
+			menuItem, err := s.foodShopRepository.FindMenuItemByCode(code)
+			if err != nil {
+				return nil, fmt.Errorf("find reward item %s for promotion %s: %w", code, rule.Code(), err)
+			}
+			if checkAvailable(menuItem, now) != nil {
+				continue
+			}
+
+			left, tracked, err := s.stockLeft(code)
+			if err != nil {
+				return nil, err
+			}
+			if tracked {
+				missing = min(missing, left-ctx.QtyByCode[code])
+				if missing < 1 {
+					continue
+				}
+			}
+
+			menuPrice := menuItem.PriceAt(now)
+			lineTotal, err := menuPrice.Price.MulIntChecked(missing)
+			if err != nil {
+				return nil, amountError("reward line "+string(code), err)
+			}
+
+			ctx.PriceByCode[code] = menuPrice.Price
+			ctx.QtyByCode[code] += missing
+
+			lines = append(lines, _foodShopModel.OrderLine{
+				Code:      code,
+				Name:      menuItem.Name,
+				Qty:       missing,
+				MenuPrice: menuPrice,
+				UnitPrice: menuPrice.Price,
+				LineTotal: lineTotal,
+			})
+		}
+	}
+	return lines, nil
+}
+
+// markFreeUnits spreads each rule's free units over the lines of that code,
+// first line first.
+func markFreeUnits(lines []_foodShopModel.OrderLine, results []_foodShopPromotion.Result) {
+	for _, result := range results {
+		for code, free := range result.FreeUnits {
+			for i := range lines {
+				if free == 0 {
+					break
+				}
+				if lines[i].Code != code {
+					continue
+				}
+				take := min(free, lines[i].Qty-lines[i].FreeQty)
+				lines[i].FreeQty += take
+				free -= take
+			}
+		}
+	}
+}
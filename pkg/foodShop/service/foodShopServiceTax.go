@@ -0,0 +1,89 @@
+package service
+
+import (
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+)
+
+// taxedTotal is a quote total with the service charge and VAT added.
+// preTax + serviceCharge + vat is always grandTotal.
+type taxedTotal struct {
+	preTax        domain.Money
+	serviceCharge domain.Money
+	vat           domain.Money
+	grandTotal    domain.Money
+}
+
+// applyTaxes adds the service charge and VAT to total, the amount left
+// after every discount, following s.tax:
+//
+//   - the charges are worked out on total, or on subtotal when the policy
+//     charges before discounts;
+//   - with VAT-exclusive prices the service charge is a share of that base
+//     and VAT a share of the base plus the service charge;
+//   - with VAT-inclusive prices the VAT already in the base is split out
+//     first, the service charge is a share of the base without VAT and only
+//     the VAT on the service charge is added on top. The VAT reported as
+//     included is always the VAT in total, the amount actually charged,
+//     even when the service charge is worked out before discounts.
+func (s *foodShopServiceImpl) applyTaxes(subtotal, total domain.Money) (taxedTotal, error) {
+	mode := s.rounding.Tax
+	base := total
+	if s.tax.Base == _foodShopModel.TaxBaseBeforeDiscounts {
+		base = subtotal
+	}
+
+	inclusive := s.tax.Pricing == _foodShopModel.MenuPricingVATInclusive
+	withoutVAT := func(amount domain.Money) (domain.Money, error) {
+		if !inclusive {
+			return amount, nil
+		}
+		net, err := amount.DivRate(domain.BasisPointsPerWhole+s.tax.VATRate, mode)
+		if err != nil {
+			return 0, amountError("VAT", err)
+		}
+		return net, nil
+	}
+
+	netBase, err := withoutVAT(base)
+	if err != nil {
+		return taxedTotal{}, err
+	}
+	netTotal, err := withoutVAT(total)
+	if err != nil {
+		return taxedTotal{}, err
+	}
+	includedVAT := total.Sub(netTotal)
+
+	serviceCharge, err := netBase.MulRate(s.tax.ServiceChargeRate, mode)
+	if err != nil {
+		return taxedTotal{}, amountError("service charge", err)
+	}
+
+	vatBase := serviceCharge
+	if !inclusive {
+		if vatBase, err = netBase.AddChecked(serviceCharge); err != nil {
+			return taxedTotal{}, amountError("service charge", err)
+		}
+	}
+	addedVAT, err := vatBase.MulRate(s.tax.VATRate, mode)
+	if err != nil {
+		return taxedTotal{}, amountError("VAT", err)
+	}
+
+	grandTotal, err := total.AddChecked(serviceCharge)
+	if err == nil {
+		grandTotal, err = grandTotal.AddChecked(addedVAT)
+	}
+	if err != nil {
+		return taxedTotal{}, amountError("grand total", err)
+	}
+
+	vat := includedVAT.Add(addedVAT)
+	return taxedTotal{
+		preTax:        grandTotal.Sub(serviceCharge).Sub(vat),
+		serviceCharge: serviceCharge,
+		vat:           vat,
+		grandTotal:    grandTotal,
+	}, nil
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	_foodShopException "github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	_taxInvoiceModel "github.com/TewApirat/food-shop/pkg/taxInvoice/model"
+)
+
+// IssueTaxInvoice issues a full tax invoice to the buyer for an order in
+// the history. The buyer is checked and the order looked up before the
+// repository assigns a number, so a rejected request never uses one up.
+// The lookup and the issue hold orderMu, so the order cannot be cancelled
+// in between.
+func (s *foodShopServiceImpl) IssueTaxInvoice(req _taxInvoiceModel.IssueRequest) (_taxInvoiceModel.TaxInvoice, error) {
+	if s.taxInvoiceRepository == nil {
+		return _taxInvoiceModel.TaxInvoice{}, &_foodShopException.TaxInvoiceNotAvailableError{
+			OrderNo: req.OrderNo,
+			Reason:  "tax invoices are not enabled",
+		}
+	}
+
+	buyer, err := normalizeBuyer(req.Buyer)
+	if err != nil {
+		return _taxInvoiceModel.TaxInvoice{}, err
+	}
+
+	s.orderMu.Lock()
+	defer s.orderMu.Unlock()
+
+	entry, err := s.orderHistoryRepository.FindByOrderNo(req.OrderNo)
+	if err != nil {
+		var notFound *_foodShopException.OrderNotFoundError
+		if errors.As(err, &notFound) {
+			return _taxInvoiceModel.TaxInvoice{}, err
+		}
+		return _taxInvoiceModel.TaxInvoice{}, fmt.Errorf("find order #%d: %w", req.OrderNo, err)
+	}
+
+	if entry.Cancelled() {
+		return _taxInvoiceModel.TaxInvoice{}, &_foodShopException.TaxInvoiceNotAvailableError{
+			OrderNo: entry.OrderNo,
+			Reason:  "it was cancelled",
+		}
+	}
+	if entry.Tax.VATRate <= 0 {
+		return _taxInvoiceModel.TaxInvoice{}, &_foodShopException.TaxInvoiceNotAvailableError{
+			OrderNo: entry.OrderNo,
+			Reason:  "it was priced without VAT",
+		}
+	}
+
+	invoice := _taxInvoiceModel.FromOrder(entry, s.seller, buyer, s.clock.Now())
+	return s.taxInvoiceRepository.Issue(invoice)
+}
+
+// GetTaxInvoice finds an issued invoice by number for reprinting.
+func (s *foodShopServiceImpl) GetTaxInvoice(number string) (_taxInvoiceModel.TaxInvoice, error) {
+	number = strings.ToUpper(strings.TrimSpace(number))
+	if s.taxInvoiceRepository == nil {
+		return _taxInvoiceModel.TaxInvoice{}, &_foodShopException.TaxInvoiceNotFoundError{Number: number}
+	}
+	return s.taxInvoiceRepository.FindByNumber(number)
+}
+
+// ListTaxInvoices lists every issued invoice in number order.
+func (s *foodShopServiceImpl) ListTaxInvoices() ([]_taxInvoiceModel.TaxInvoice, error) {
+	if s.taxInvoiceRepository == nil {
+		return nil, nil
+	}
+	return s.taxInvoiceRepository.List()
+}
+
+// normalizeBuyer trims the buyer's details and checks what a full tax
+// invoice needs: a name, an address and a valid tax ID. The tax ID may be
+// written with dashes or spaces; no branch means the head office.
+func normalizeBuyer(buyer _taxInvoiceModel.Party) (_taxInvoiceModel.Party, error) {
+	buyer.Name = strings.TrimSpace(buyer.Name)
+	buyer.Address = strings.TrimSpace(buyer.Address)
+	buyer.TaxID = strings.NewReplacer("-", "", " ", "").Replace(buyer.TaxID)
+	buyer.Branch = strings.TrimSpace(buyer.Branch)
+
+	if buyer.Name == "" {
+		return _taxInvoiceModel.Party{}, &_foodShopException.InvalidBuyerError{Field: "name", Reason: "is required"}
+	}
+	if buyer.Address == "" {
+		return _taxInvoiceModel.Party{}, &_foodShopException.InvalidBuyerError{Field: "address", Reason: "is required"}
+	}
+	if !_taxInvoiceModel.ValidTaxID(buyer.TaxID) {
+		return _taxInvoiceModel.Party{}, &_foodShopException.InvalidBuyerError{Field: "tax ID", Reason: "must be 13 digits with a valid check digit"}
+	}
+	if buyer.Branch == "" {
+		buyer.Branch = _taxInvoiceModel.HeadOfficeBranch
+	}
+	if !_taxInvoiceModel.ValidBranch(buyer.Branch) {
+		return _taxInvoiceModel.Party{}, &_foodShopException.InvalidBuyerError{Field: "branch", Reason: "must be 5 digits"}
+	}
+	return buyer, nil
+}
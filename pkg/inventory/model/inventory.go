@@ -0,0 +1,15 @@
+package model
+
+import (
+	"time"
+
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+)
+
+// Reservation is the stock held for one order, by menu item code. It only
+// lists stock-tracked items; cancelling the order puts the stock back.
+type Reservation struct {
+	OrderNo    int
+	Items      map[_foodShopModel.MenuItemCode]int
+	ReservedAt time.Time
+}
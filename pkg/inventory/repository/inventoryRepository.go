@@ -0,0 +1,12 @@
+package repository
+
+import (
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	"github.com/TewApirat/food-shop/pkg/inventory/model"
+)
+
+type InventoryRepository interface {
+	List() ([]_foodShopModel.StockLevel, error)
+	Reserve(reservation model.Reservation) ([]_foodShopModel.StockLevel, error)
+	Release(orderNo int) (model.Reservation, error)
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	"github.com/TewApirat/food-shop/pkg/inventory/model"
+)
+
+type inventoryRepositoryImpl struct {
+	mu           sync.Mutex
+	remaining    map[_foodShopModel.MenuItemCode]int
+	reservations map[int]model.Reservation
+}
+
+// NewInventoryRepositoryImpl tracks stock for the items listed; every
+// other menu item is unlimited.
+func NewInventoryRepositoryImpl(stock []_foodShopModel.StockLevel) InventoryRepository {
+	remaining := make(map[_foodShopModel.MenuItemCode]int, len(stock))
+	for _, level := range stock {
+		remaining[level.Code] = level.Remaining
+	}
+
+	return &inventoryRepositoryImpl{
+		remaining:    remaining,
+		reservations: make(map[int]model.Reservation),
+	}
+}
+
+// DefaultStock is the opening stock of the sets the kitchen prepares in
+// batches. Drinks and the other items are made to order and unlimited.
+func DefaultStock() []_foodShopModel.StockLevel {
+	return []_foodShopModel.StockLevel{
+		{Code: "PINK", Remaining: 10},
+		{Code: "PURPLE", Remaining: 5},
+		{Code: "ORANGE", Remaining: 10},
+		{Code: "MANGO", Remaining: 12},
+	}
+}
+
+// List returns the stock-tracked items by code.
+func (r *inventoryRepositoryImpl) List() ([]_foodShopModel.StockLevel, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	codes := make([]_foodShopModel.MenuItemCode, 0, len(r.remaining))
+	for code := range r.remaining {
+		codes = append(codes, code)
+	}
+	return r.levelsLocked(codes), nil
+}
+
+// Reserve takes the stock for every tracked item of the reservation or
+// none of it, checking under the lock so two concurrent orders cannot both
+// take the last unit. It returns what is left of the reserved items.
+func (r *inventoryRepositoryImpl) Reserve(reservation model.Reservation) ([]_foodShopModel.StockLevel, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.reservations[reservation.OrderNo]; ok {
+		return nil, fmt.Errorf("order #%d already holds stock", reservation.OrderNo)
+	}
+
+	tracked := make([]_foodShopModel.MenuItemCode, 0, len(reservation.Items))
+	for code := range reservation.Items {
+		if _, ok := r.remaining[code]; ok {
+			tracked = append(tracked, code)
+		}
+	}
+	sort.Slice(tracked, func(i, j int) bool { return tracked[i] < tracked[j] })
+
+	held := make(map[_foodShopModel.MenuItemCode]int, len(tracked))
+	for _, code := range tracked {
+		qty := reservation.Items[code]
+		if left := r.remaining[code]; qty > left {
+			return nil, &exception.OutOfStockError{Code: string(code), Requested: qty, Remaining: left}
+		}
+		held[code] = qty
+	}
+
+	for code, qty := range held {
+		r.remaining[code] -= qty
+	}
+	if len(held) > 0 {
+		reservation.Items = held
+		r.reservations[reservation.OrderNo] = reservation
+	}
+	return r.levelsLocked(tracked), nil
+}
+
+// Release puts back the stock held for an order and returns what was
+// released. Releasing an order that holds nothing is a no-op.
+func (r *inventoryRepositoryImpl) Release(orderNo int) (model.Reservation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reservation, ok := r.reservations[orderNo]
+	if !ok {
+		return model.Reservation{OrderNo: orderNo}, nil
+	}
+
+	for code, qty := range reservation.Items {
+		r.remaining[code] += qty
+	}
+	delete(r.reservations, orderNo)
+	return reservation, nil
+}
+
+func (r *inventoryRepositoryImpl) levelsLocked(codes []_foodShopModel.MenuItemCode) []_foodShopModel.StockLevel {
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+
+	levels := make([]_foodShopModel.StockLevel, 0, len(codes))
+	for _, code := range codes {
+		levels = append(levels, _foodShopModel.StockLevel{Code: code, Remaining: r.remaining[code]})
+	}
+	return levels
+}
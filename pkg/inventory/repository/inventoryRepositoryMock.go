@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	"github.com/TewApirat/food-shop/pkg/inventory/model"
+)
+
+type InventoryRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *InventoryRepositoryMock) List() ([]_foodShopModel.StockLevel, error) {
+	args := m.Called()
+	return args.Get(0).([]_foodShopModel.StockLevel), args.Error(1)
+}
+
+func (m *InventoryRepositoryMock) Reserve(reservation model.Reservation) ([]_foodShopModel.StockLevel, error) {
+	args := m.Called(reservation)
+	return args.Get(0).([]_foodShopModel.StockLevel), args.Error(1)
+}
+
+func (m *InventoryRepositoryMock) Release(orderNo int) (model.Reservation, error) {
+	args := m.Called(orderNo)
+	return args.Get(0).(model.Reservation), args.Error(1)
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+)
+
+type MemberTier string
+
+const (
+	MemberTierSilver   MemberTier = "SILVER"
+	MemberTierGold     MemberTier = "GOLD"
+	MemberTierPlatinum MemberTier = "PLATINUM"
+)
+
+// DiscountRate is the member discount a tier earns; unknown tiers earn none.
+func (t MemberTier) DiscountRate() domain.Rate {
+	switch t {
+	case MemberTierSilver:
+		return domain.PercentRate(5)
+	case MemberTierGold:
+		return domain.PercentRate(10)
+	case MemberTierPlatinum:
+		return domain.PercentRate(15)
+	default:
+		return 0
+	}
+}
+
+// Member is a loyalty card holder. A zero ExpiresAt never expires.
+type Member struct {
+	ID         string
+	CardNumber string
+	Name       string
+	Tier       MemberTier
+	ExpiresAt  time.Time
+	Suspended  bool
+}
@@ -0,0 +1,8 @@
+package repository
+
+import "github.com/TewApirat/food-shop/pkg/member/model"
+
+type MemberRepository interface {
+	// FindMember looks a member up by member ID or card number.
+	FindMember(idOrCardNumber string) (model.Member, error)
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"sync"
+	"time"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	"github.com/TewApirat/food-shop/pkg/member/model"
+)
+
+type memberRepositoryImpl struct {
+	mu           sync.Mutex
+	byID         map[string]model.Member
+	byCardNumber map[string]string
+}
+
+func NewMemberRepositoryImpl(members []model.Member) MemberRepository {
+	byID := make(map[string]model.Member, len(members))
+	byCardNumber := make(map[string]string, len(members))
+	for _, member := range members {
+		byID[member.ID] = member
+		if member.CardNumber != "" {
+			byCardNumber[member.CardNumber] = member.ID
+		}
+	}
+
+	return &memberRepositoryImpl{
+		byID:         byID,
+		byCardNumber: byCardNumber,
+	}
+}
+
+func DefaultMembers() []model.Member {
+	return []model.Member{
+		{ID: "M0001", CardNumber: "6001000000000001", Name: "Somchai", Tier: model.MemberTierSilver},
+		{ID: "M0002", CardNumber: "6001000000000002", Name: "Suda", Tier: model.MemberTierGold},
+		{ID: "M0003", CardNumber: "6001000000000003", Name: "Anan", Tier: model.MemberTierPlatinum},
+		{
+			ID:         "M0004",
+			CardNumber: "6001000000000004",
+			Name:       "Malee",
+			Tier:       model.MemberTierGold,
+			ExpiresAt:  time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func (r *memberRepositoryImpl) FindMember(idOrCardNumber string) (model.Member, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if member, ok := r.byID[idOrCardNumber]; ok {
+		return member, nil
+	}
+	if id, ok := r.byCardNumber[idOrCardNumber]; ok {
+		return r.byID[id], nil
+	}
+	return model.Member{}, &exception.UnknownMemberError{ID: idOrCardNumber}
+}
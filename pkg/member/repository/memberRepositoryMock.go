@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TewApirat/food-shop/pkg/member/model"
+)
+
+type MemberRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *MemberRepositoryMock) FindMember(idOrCardNumber string) (model.Member, error) {
+	args := m.Called(idOrCardNumber)
+	return args.Get(0).(model.Member), args.Error(1)
+}
@@ -5,18 +5,38 @@ import (
 
 	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
 	"github.com/TewApirat/food-shop/pkg/foodShop/model"
-	
-
+	_memberModel "github.com/TewApirat/food-shop/pkg/member/model"
 )
 
 type OrderHistoryEntry struct {
 	OrderNo    int
 	CreatedAt  time.Time
-	Member     bool
+	MemberID   string
+	MemberTier _memberModel.MemberTier
+	CustomerID string
 
-	Line 		   []model.OrderLine
+	Line           []model.OrderLine
 	Subtotal       domain.Money
 	PairDiscount   domain.Money
-	MemberDiscount domain.Money
-	Total          domain.Money
+	MemberDiscount    domain.Money
+	PromotionDiscount domain.Money
+	CouponDiscount    domain.Money
+	AppliedPromotions []model.AppliedPromotion
+	Coupons           []model.AppliedCoupon
+	Total             domain.Money
+	PreTax            domain.Money
+	ServiceCharge     domain.Money
+	VAT               domain.Money
+	GrandTotal        domain.Money
+	Tax               model.TaxPolicy
+	CashRounding      domain.Money
+	CashTotal         domain.Money
+	Rounding          model.RoundingStrategy
+	Display           *model.DisplayTotal
+	// CancelledAt is when the order was cancelled, zero while it stands.
+	CancelledAt time.Time
+}
+
+func (e OrderHistoryEntry) Cancelled() bool {
+	return !e.CancelledAt.IsZero()
 }
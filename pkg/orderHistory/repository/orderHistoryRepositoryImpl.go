@@ -2,7 +2,9 @@ package repository
 
 import (
 	"sync"
+	"time"
 
+	"github.com/TewApirat/food-shop/pkg/foodShop/exception"
 	"github.com/TewApirat/food-shop/pkg/orderHistory/model"
 )
 
@@ -33,8 +35,39 @@ func (r *orderHistoryRepositoryImpl) List() ([]model.OrderHistoryEntry, error) {
 	return out, nil
 }
 
+func (r *orderHistoryRepositoryImpl) FindByOrderNo(orderNo int) (model.OrderHistoryEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, entry := range r.entries {
+		if entry.OrderNo == orderNo {
+			return entry, nil
+		}
+	}
+	return model.OrderHistoryEntry{}, &exception.OrderNotFoundError{OrderNo: orderNo}
+}
+
 func (r *orderHistoryRepositoryImpl) Count() (int, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	return len(r.entries), nil
 }
+
+// Cancel marks an order as cancelled at the given time and returns the
+// updated entry. An order can only be cancelled once.
+func (r *orderHistoryRepositoryImpl) Cancel(orderNo int, at time.Time) (model.OrderHistoryEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, entry := range r.entries {
+		if entry.OrderNo != orderNo {
+			continue
+		}
+		if entry.Cancelled() {
+			return model.OrderHistoryEntry{}, &exception.OrderAlreadyCancelledError{OrderNo: orderNo, CancelledAt: entry.CancelledAt}
+		}
+		r.entries[i].CancelledAt = at
+		return r.entries[i], nil
+	}
+	return model.OrderHistoryEntry{}, &exception.OrderNotFoundError{OrderNo: orderNo}
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/stretchr/testify/mock"
 
 	"github.com/TewApirat/food-shop/pkg/orderHistory/model"
@@ -20,7 +22,17 @@ func (m *OrderHistoryRepositoryMock)List() ([]model.OrderHistoryEntry, error){
 	return args.Get(0).([]model.OrderHistoryEntry), args.Error(1)
 }
 
+func (m *OrderHistoryRepositoryMock)FindByOrderNo(orderNo int) (model.OrderHistoryEntry, error){
+	args := m.Called(orderNo)
+	return args.Get(0).(model.OrderHistoryEntry), args.Error(1)
+}
+
 func (m *OrderHistoryRepositoryMock)Count() (int, error){
 	args := m.Called()
 	return args.Get(0).(int), args.Error(1)
 }
+
+func (m *OrderHistoryRepositoryMock)Cancel(orderNo int, at time.Time) (model.OrderHistoryEntry, error){
+	args := m.Called(orderNo, at)
+	return args.Get(0).(model.OrderHistoryEntry), args.Error(1)
+}
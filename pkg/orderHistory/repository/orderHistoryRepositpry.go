@@ -1,9 +1,15 @@
 package repository
 
-import "github.com/TewApirat/food-shop/pkg/orderHistory/model"
+import (
+	"time"
+
+	"github.com/TewApirat/food-shop/pkg/orderHistory/model"
+)
 
 type OrderHistoryRepository interface {
 	Add(entry model.OrderHistoryEntry) error
 	List() ([]model.OrderHistoryEntry, error)
+	FindByOrderNo(orderNo int) (model.OrderHistoryEntry, error)
 	Count() (int, error)
+	Cancel(orderNo int, at time.Time) (model.OrderHistoryEntry, error)
 }
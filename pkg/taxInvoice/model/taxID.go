@@ -0,0 +1,30 @@
+package model
+
+// ValidTaxID reports whether id is a 13-digit Thai taxpayer ID with a
+// correct check digit: the first twelve digits weighted 13 down to 2,
+// summed, and the check digit is (11 - sum%11) % 10.
+func ValidTaxID(id string) bool {
+	if len(id) != 13 || !allDigits(id) {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < 12; i++ {
+		sum += int(id[i]-'0') * (13 - i)
+	}
+	return int(id[12]-'0') == (11-sum%11)%10
+}
+
+// ValidBranch reports whether branch is a 5-digit branch number.
+func ValidBranch(branch string) bool {
+	return len(branch) == 5 && allDigits(branch)
+}
+
+func allDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"time"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_orderHistoryModel "github.com/TewApirat/food-shop/pkg/orderHistory/model"
+)
+
+// HeadOfficeBranch is the branch number the Revenue Department uses for a
+// company's head office.
+const HeadOfficeBranch = "00000"
+
+// Party is the seller or buyer named on a full tax invoice. TaxID is the
+// 13-digit taxpayer ID and Branch the 5-digit branch number.
+type Party struct {
+	Name    string `json:"name"`
+	TaxID   string `json:"taxId"`
+	Branch  string `json:"branch"`
+	Address string `json:"address"`
+}
+
+// BranchLabel reads the branch the way it is printed on an invoice.
+func (p Party) BranchLabel() string {
+	if p.Branch == "" || p.Branch == HeadOfficeBranch {
+		return "Head office"
+	}
+	return "Branch " + p.Branch
+}
+
+// DefaultSeller is the shop printed on invoices when no other seller is
+// configured.
+func DefaultSeller() Party {
+	return Party{
+		Name:    "Food Shop Co., Ltd.",
+		TaxID:   "0105556123453",
+		Branch:  HeadOfficeBranch,
+		Address: "99 Sukhumvit Road, Khlong Toei, Bangkok 10110",
+	}
+}
+
+// IssueRequest asks for a full tax invoice for an order in the history.
+type IssueRequest struct {
+	OrderNo int   `json:"orderNo"`
+	Buyer   Party `json:"buyer"`
+}
+
+// InvoiceLine is one item on a tax invoice at its unit price, options
+// included; discounts are shown once, as a total, below the lines. Name
+// lists the chosen options after the item name.
+type InvoiceLine struct {
+	Code      _foodShopModel.MenuItemCode `json:"code"`
+	Name      string                      `json:"name"`
+	Qty       int                         `json:"qty"`
+	UnitPrice domain.Money                `json:"unitPrice"`
+	Amount    domain.Money                `json:"amount"`
+}
+
+// TaxInvoice is a full tax invoice issued for one order. Number is
+// assigned from a gap-free sequence when the invoice is stored. Discount
+// is every promotion and coupon on the order; TaxableAmount is what VAT
+// was charged on, so TaxableAmount + VAT = GrandTotal.
+type TaxInvoice struct {
+	Number    string    `json:"number"`
+	Sequence  int       `json:"sequence"`
+	IssuedAt  time.Time `json:"issuedAt"`
+	OrderNo   int       `json:"orderNo"`
+	OrderedAt time.Time `json:"orderedAt"`
+	Seller    Party     `json:"seller"`
+	Buyer     Party     `json:"buyer"`
+
+	Lines         []InvoiceLine            `json:"lines"`
+	Subtotal      domain.Money             `json:"subtotal"`
+	Discount      domain.Money             `json:"discount"`
+	Total         domain.Money             `json:"total"`
+	ServiceCharge domain.Money             `json:"serviceCharge"`
+	TaxableAmount domain.Money             `json:"taxableAmount"`
+	VAT           domain.Money             `json:"vat"`
+	GrandTotal    domain.Money             `json:"grandTotal"`
+	Tax           _foodShopModel.TaxPolicy `json:"tax"`
+}
+
+// FromOrder builds the invoice for a history entry. Number and Sequence
+// are left for the repository to assign.
+func FromOrder(entry _orderHistoryModel.OrderHistoryEntry, seller, buyer Party, issuedAt time.Time) TaxInvoice {
+	lines := make([]InvoiceLine, 0, len(entry.Line))
+	for _, ln := range entry.Line {
+		name := ln.Name
+		if len(ln.Options) > 0 {
+			name += " (" + _foodShopModel.OptionNames(ln.Options) + ")"
+		}
+		lines = append(lines, InvoiceLine{
+			Code:      ln.Code,
+			Name:      name,
+			Qty:       ln.Qty,
+			UnitPrice: ln.UnitPrice,
+			Amount:    ln.LineTotal,
+		})
+	}
+
+	return TaxInvoice{
+		IssuedAt:      issuedAt,
+		OrderNo:       entry.OrderNo,
+		OrderedAt:     entry.CreatedAt,
+		Seller:        seller,
+		Buyer:         buyer,
+		Lines:         lines,
+		Subtotal:      entry.Subtotal,
+		Discount:      entry.Subtotal.Sub(entry.Total),
+		Total:         entry.Total,
+		ServiceCharge: entry.ServiceCharge,
+		TaxableAmount: entry.GrandTotal.Sub(entry.VAT),
+		VAT:           entry.VAT,
+		GrandTotal:    entry.GrandTotal,
+		Tax:           entry.Tax,
+	}
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+)
+
+const invoiceTimeLayout = "2006-01-02 15:04"
+
+// Text renders the invoice for printing. It carries what section 86/4 of
+// the Revenue Code asks of a full tax invoice: the words "Tax Invoice",
+// seller and buyer name, address, tax ID and branch, the invoice number
+// and date, the items, and VAT shown apart from the amount it is on. The
+// grand total is also spelled out in Thai and English.
+func (inv TaxInvoice) Text() string {
+	var b strings.Builder
+
+	fmt.Fprintln(&b, "TAX INVOICE / ใบกำกับภาษีเต็มรูป")
+	fmt.Fprintf(&b, "%-16s : %s\n", "No.", inv.Number)
+	fmt.Fprintf(&b, "%-16s : %s\n", "Date", inv.IssuedAt.Format(invoiceTimeLayout))
+	fmt.Fprintf(&b, "%-16s : #%d (%s)\n", "Order", inv.OrderNo, inv.OrderedAt.Format(invoiceTimeLayout))
+
+	fmt.Fprintln(&b)
+	writeParty(&b, "Seller", inv.Seller)
+	fmt.Fprintln(&b)
+	writeParty(&b, "Buyer", inv.Buyer)
+
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "CODE    | DESCRIPTION  | QTY | UNIT PRICE | AMOUNT")
+	fmt.Fprintln(&b, "--------+--------------+-----+------------+-----------")
+	for _, ln := range inv.Lines {
+		fmt.Fprintf(&b, "%-7s | %-12s | %3d | %-10s | %s\n",
+			ln.Code, ln.Name, ln.Qty, ln.UnitPrice.String(), ln.Amount.String())
+	}
+
+	fmt.Fprintln(&b)
+	fmt.Fprintf(&b, "%-16s : %s\n", "Subtotal", inv.Subtotal.String())
+	fmt.Fprintf(&b, "%-16s : %s\n", "Discount", inv.Discount.String())
+	fmt.Fprintf(&b, "%-16s : %s\n", "Total", inv.Total.String())
+	if inv.Tax.ServiceChargeRate > 0 {
+		fmt.Fprintf(&b, "%-16s : %s (%s)\n", "Service Charge", inv.ServiceCharge.String(), inv.Tax.ServiceChargeRate)
+	}
+	fmt.Fprintf(&b, "%-16s : %s\n", "Before VAT", inv.TaxableAmount.String())
+	fmt.Fprintf(&b, "%-16s : %s (%s)\n", "VAT", inv.VAT.String(), inv.Tax.VATRate)
+	fmt.Fprintf(&b, "%-16s : %s\n", "Grand Total", inv.GrandTotal.String())
+	fmt.Fprintf(&b, "%-16s : %s\n", "In words", inv.GrandTotal.ThaiWords())
+	fmt.Fprintf(&b, "%-16s   %s\n", "", inv.GrandTotal.EnglishWords())
+	if inv.Tax.Pricing == _foodShopModel.MenuPricingVATInclusive {
+		fmt.Fprintln(&b, "Menu prices include VAT.")
+	}
+
+	return b.String()
+}
+
+// JSON renders the invoice as indented JSON; amounts are baht decimal
+// strings.
+func (inv TaxInvoice) JSON() ([]byte, error) {
+	return json.MarshalIndent(inv, "", "  ")
+}
+
+func writeParty(b *strings.Builder, label string, p Party) {
+	fmt.Fprintf(b, "%-16s : %s (%s)\n", label, p.Name, p.BranchLabel())
+	fmt.Fprintf(b, "%-16s : %s\n", "Tax ID", p.TaxID)
+	fmt.Fprintf(b, "%-16s : %s\n", "Address", p.Address)
+}
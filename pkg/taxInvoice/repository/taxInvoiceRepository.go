@@ -0,0 +1,10 @@
+package repository
+
+import "github.com/TewApirat/food-shop/pkg/taxInvoice/model"
+
+type TaxInvoiceRepository interface {
+	Issue(invoice model.TaxInvoice) (model.TaxInvoice, error)
+	FindByNumber(number string) (model.TaxInvoice, error)
+	FindByOrderNo(orderNo int) (model.TaxInvoice, error)
+	List() ([]model.TaxInvoice, error)
+}
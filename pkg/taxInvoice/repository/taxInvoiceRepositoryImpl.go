@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	"github.com/TewApirat/food-shop/pkg/taxInvoice/model"
+)
+
+// DefaultNumberPrefix starts every invoice number, e.g. "TI000001".
+const DefaultNumberPrefix = "TI"
+
+type taxInvoiceRepositoryImpl struct {
+	mu       sync.Mutex
+	prefix   string
+	invoices []model.TaxInvoice
+}
+
+func NewTaxInvoiceRepositoryImpl(prefix string) TaxInvoiceRepository {
+	return &taxInvoiceRepositoryImpl{
+		prefix:   prefix,
+		invoices: make([]model.TaxInvoice, 0),
+	}
+}
+
+// Issue numbers and stores the invoice in one step, so a number is only
+// ever taken by an invoice that was kept and the sequence has no gaps.
+// An order gets at most one invoice.
+func (r *taxInvoiceRepositoryImpl) Issue(invoice model.TaxInvoice) (model.TaxInvoice, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, issued := range r.invoices {
+		if issued.OrderNo == invoice.OrderNo {
+			return model.TaxInvoice{}, &exception.TaxInvoiceAlreadyIssuedError{OrderNo: issued.OrderNo, Number: issued.Number}
+		}
+	}
+
+	invoice.Sequence = len(r.invoices) + 1
+	invoice.Number = fmt.Sprintf("%s%06d", r.prefix, invoice.Sequence)
+	r.invoices = append(r.invoices, invoice)
+	return invoice, nil
+}
+
+func (r *taxInvoiceRepositoryImpl) FindByNumber(number string) (model.TaxInvoice, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, invoice := range r.invoices {
+		if invoice.Number == number {
+			return invoice, nil
+		}
+	}
+	return model.TaxInvoice{}, &exception.TaxInvoiceNotFoundError{Number: number}
+}
+
+func (r *taxInvoiceRepositoryImpl) FindByOrderNo(orderNo int) (model.TaxInvoice, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, invoice := range r.invoices {
+		if invoice.OrderNo == orderNo {
+			return invoice, nil
+		}
+	}
+	return model.TaxInvoice{}, &exception.TaxInvoiceNotFoundError{OrderNo: orderNo}
+}
+
+// List returns the issued invoices in number order.
+func (r *taxInvoiceRepositoryImpl) List() ([]model.TaxInvoice, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]model.TaxInvoice, len(r.invoices))
+	copy(out, r.invoices)
+	return out, nil
+}
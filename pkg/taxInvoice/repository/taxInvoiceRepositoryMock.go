@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TewApirat/food-shop/pkg/taxInvoice/model"
+)
+
+type TaxInvoiceRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *TaxInvoiceRepositoryMock) Issue(invoice model.TaxInvoice) (model.TaxInvoice, error) {
+	args := m.Called(invoice)
+	return args.Get(0).(model.TaxInvoice), args.Error(1)
+}
+
+func (m *TaxInvoiceRepositoryMock) FindByNumber(number string) (model.TaxInvoice, error) {
+	args := m.Called(number)
+	return args.Get(0).(model.TaxInvoice), args.Error(1)
+}
+
+func (m *TaxInvoiceRepositoryMock) FindByOrderNo(orderNo int) (model.TaxInvoice, error) {
+	args := m.Called(orderNo)
+	return args.Get(0).(model.TaxInvoice), args.Error(1)
+}
+
+func (m *TaxInvoiceRepositoryMock) List() ([]model.TaxInvoice, error) {
+	args := m.Called()
+	return args.Get(0).([]model.TaxInvoice), args.Error(1)
+}
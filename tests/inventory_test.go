@@ -0,0 +1,505 @@
+package tests
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	_couponModel "github.com/TewApirat/food-shop/pkg/coupon/model"
+	_couponRepository "github.com/TewApirat/food-shop/pkg/coupon/repository"
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopException "github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
+	_inventoryModel "github.com/TewApirat/food-shop/pkg/inventory/model"
+	_inventoryRepository "github.com/TewApirat/food-shop/pkg/inventory/repository"
+	_orderHistoryModel "github.com/TewApirat/food-shop/pkg/orderHistory/model"
+	_orderHistoryRepository "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
+	_taxInvoiceModel "github.com/TewApirat/food-shop/pkg/taxInvoice/model"
+	_taxInvoiceRepository "github.com/TewApirat/food-shop/pkg/taxInvoice/repository"
+)
+
+var inventoryTestNow = time.Date(2026, time.October, 17, 18, 0, 0, 0, time.UTC)
+
+// newInventoryTestService sells PURPLE from a stock of 5 and everything
+// else without limit.
+func newInventoryTestService(opts ..._foodShopService.Option) (_foodShopService.FoodShopService, _inventoryRepository.InventoryRepository) {
+	inventoryRepository := _inventoryRepository.NewInventoryRepositoryImpl([]_foodShopModel.StockLevel{
+		{Code: "PURPLE", Remaining: 5},
+	})
+
+	opts = append([]_foodShopService.Option{
+		_foodShopService.WithClock(domain.FixedClock(inventoryTestNow)),
+		_foodShopService.WithInventoryRepository(inventoryRepository),
+	}, opts...)
+	foodShopService := _foodShopService.NewFoodShopServiceImpl(
+		_foodShopRepository.NewFoodShopRepositoryDefault(),
+		_orderHistoryRepository.NewOrderHistoryRepositoryImpl(),
+		opts...,
+	)
+	return foodShopService, inventoryRepository
+}
+
+func TestQuoteOrder_Stock(t *testing.T) {
+	type tc struct {
+		label         string
+		orders        []map[string]int
+		expectedStock []_foodShopModel.StockLevel
+		expectedErr   *_foodShopException.OutOfStockError
+		expectedCount int
+	}
+
+	cases := []tc{
+		{
+			label:         "Success: tracked items report what is left, unlimited items are not listed",
+			orders:        []map[string]int{{"PURPLE": 2, "RED": 100}},
+			expectedStock: []_foodShopModel.StockLevel{{Code: "PURPLE", Remaining: 3}},
+			expectedCount: 1,
+		},
+		{
+			label:         "Success: only unlimited items",
+			orders:        []map[string]int{{"RED": 1}},
+			expectedStock: []_foodShopModel.StockLevel{},
+			expectedCount: 1,
+		},
+		{
+			label:         "Success: the last units",
+			orders:        []map[string]int{{"PURPLE": 3}, {"PURPLE": 2}},
+			expectedStock: []_foodShopModel.StockLevel{{Code: "PURPLE", Remaining: 0}},
+			expectedCount: 2,
+		},
+		{
+			label:         "Fail: more than is left",
+			orders:        []map[string]int{{"PURPLE": 4}, {"PURPLE": 2, "RED": 1}},
+			expectedErr:   &_foodShopException.OutOfStockError{Code: "PURPLE", Requested: 2, Remaining: 1},
+			expectedCount: 1,
+		},
+		{
+			label:         "Fail: sold out",
+			orders:        []map[string]int{{"PURPLE": 5}, {"PURPLE": 1}},
+			expectedErr:   &_foodShopException.OutOfStockError{Code: "PURPLE", Requested: 1, Remaining: 0},
+			expectedCount: 1,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			foodShopService, _ := newInventoryTestService()
+
+			var quote _foodShopModel.OrderQuote
+			var err error
+			for _, items := range c.orders {
+				quote, err = foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{Items: items})
+			}
+
+			if c.expectedErr != nil {
+				var target *_foodShopException.OutOfStockError
+				if assert.ErrorAs(t, err, &target) {
+					assert.Equal(t, c.expectedErr, target)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, c.expectedStock, quote.Stock)
+			}
+
+			count, err := foodShopService.CountOrderHistory()
+			assert.NoError(t, err)
+			assert.Equal(t, c.expectedCount, count)
+		})
+	}
+}
+
+func TestQuoteOrder_StockForRewardItems(t *testing.T) {
+	type tc struct {
+		label         string
+		items         map[string]int
+		redStock      int
+		expectedQty   map[_foodShopModel.MenuItemCode]int
+		expectedTotal domain.Money
+		expectedStock []_foodShopModel.StockLevel
+	}
+
+	// ORANGE2RED adds a free RED for every 2 ORANGE.
+	cases := []tc{
+		{
+			label:         "The added RED takes the last unit",
+			items:         map[string]int{"ORANGE": 2},
+			redStock:      1,
+			expectedQty:   map[_foodShopModel.MenuItemCode]int{"ORANGE": 2, "RED": 1},
+			expectedTotal: domain.THB(240),
+			expectedStock: []_foodShopModel.StockLevel{{Code: "ORANGE", Remaining: 8}, {Code: "RED", Remaining: 0}},
+		},
+		{
+			label:         "Two RED earned, one left: one added",
+			items:         map[string]int{"ORANGE": 4},
+			redStock:      1,
+			expectedQty:   map[_foodShopModel.MenuItemCode]int{"ORANGE": 4, "RED": 1},
+			expectedTotal: domain.THB(480),
+			expectedStock: []_foodShopModel.StockLevel{{Code: "ORANGE", Remaining: 6}, {Code: "RED", Remaining: 0}},
+		},
+		{
+			label:         "RED sold out: the ORANGE still sell",
+			items:         map[string]int{"ORANGE": 2},
+			expectedQty:   map[_foodShopModel.MenuItemCode]int{"ORANGE": 2},
+			expectedTotal: domain.THB(240),
+			expectedStock: []_foodShopModel.StockLevel{{Code: "ORANGE", Remaining: 8}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			promotions, err := _foodShopRepository.ParsePromotions([]byte(autoAddPromotions))
+			assert.NoError(t, err)
+
+			foodShopRepositoryMock := new(_foodShopRepository.FoodShopRepositoryMock)
+			orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+
+			foodShopRepositoryMock.Test(t)
+			orderHistoryRepositoryMock.Test(t)
+
+			for code, item := range _foodShopRepository.DefaultMenu() {
+				foodShopRepositoryMock.On("FindMenuItemByCode", code).Return(item, nil).Maybe()
+			}
+			foodShopRepositoryMock.On("ListPromotions").Return(promotions, nil).Once()
+			orderHistoryRepositoryMock.On("Add", mock.Anything).Return(nil).Once()
+
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				foodShopRepositoryMock,
+				orderHistoryRepositoryMock,
+				_foodShopService.WithClock(domain.FixedClock(inventoryTestNow)),
+				_foodShopService.WithInventoryRepository(_inventoryRepository.NewInventoryRepositoryImpl([]_foodShopModel.StockLevel{
+					{Code: "ORANGE", Remaining: 10},
+					{Code: "RED", Remaining: c.redStock},
+				})),
+			)
+
+			quote, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{Items: c.items})
+			assert.NoError(t, err)
+			assert.Equal(t, c.expectedQty, lineQtyMap(quote.Lines))
+			assert.Equal(t, c.expectedTotal, quote.Total)
+			assert.Equal(t, c.expectedStock, quote.Stock)
+			orderHistoryRepositoryMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCancelOrder(t *testing.T) {
+	foodShopService, _ := newInventoryTestService()
+
+	_, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{Items: map[string]int{"PURPLE": 5}})
+	assert.NoError(t, err)
+
+	entry, err := foodShopService.CancelOrder(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, entry.OrderNo)
+	assert.Equal(t, inventoryTestNow, entry.CancelledAt)
+
+	stock, err := foodShopService.ListStock()
+	assert.NoError(t, err)
+	assert.Equal(t, []_foodShopModel.StockLevel{{Code: "PURPLE", Remaining: 5}}, stock)
+
+	entries, err := foodShopService.ListOrderHistory()
+	assert.NoError(t, err)
+	assert.True(t, entries[0].Cancelled())
+
+	var alreadyCancelled *_foodShopException.OrderAlreadyCancelledError
+	_, err = foodShopService.CancelOrder(1)
+	assert.ErrorAs(t, err, &alreadyCancelled)
+
+	stock, err = foodShopService.ListStock()
+	assert.NoError(t, err)
+	assert.Equal(t, []_foodShopModel.StockLevel{{Code: "PURPLE", Remaining: 5}}, stock, "a second cancel must not release twice")
+
+	var notFound *_foodShopException.OrderNotFoundError
+	_, err = foodShopService.CancelOrder(9)
+	assert.ErrorAs(t, err, &notFound)
+}
+
+func TestCancelOrder_NoTaxInvoice(t *testing.T) {
+	foodShopService, _ := newInventoryTestService(
+		_foodShopService.WithTaxPolicy(_foodShopModel.ThaiTaxPolicy(_foodShopModel.MenuPricingVATExclusive)),
+		_foodShopService.WithTaxInvoiceRepository(_taxInvoiceRepository.NewTaxInvoiceRepositoryImpl("TI")),
+	)
+
+	_, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{Items: map[string]int{"RED": 1}})
+	assert.NoError(t, err)
+	_, err = foodShopService.CancelOrder(1)
+	assert.NoError(t, err)
+
+	_, err = foodShopService.IssueTaxInvoice(_taxInvoiceModel.IssueRequest{OrderNo: 1, Buyer: testBuyer()})
+	var target *_foodShopException.TaxInvoiceNotAvailableError
+	if assert.ErrorAs(t, err, &target) {
+		assert.Equal(t, "it was cancelled", target.Reason)
+	}
+}
+
+func TestCancelOrder_TaxInvoiced(t *testing.T) {
+	foodShopService, _ := newInventoryTestService(
+		_foodShopService.WithTaxPolicy(_foodShopModel.ThaiTaxPolicy(_foodShopModel.MenuPricingVATExclusive)),
+		_foodShopService.WithTaxInvoiceRepository(_taxInvoiceRepository.NewTaxInvoiceRepositoryImpl("TI")),
+	)
+
+	_, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{Items: map[string]int{"PURPLE": 2}})
+	assert.NoError(t, err)
+	invoice, err := foodShopService.IssueTaxInvoice(_taxInvoiceModel.IssueRequest{OrderNo: 1, Buyer: testBuyer()})
+	assert.NoError(t, err)
+
+	_, err = foodShopService.CancelOrder(1)
+	var target *_foodShopException.OrderNotCancellableError
+	if assert.ErrorAs(t, err, &target) {
+		assert.Equal(t, "tax invoice "+invoice.Number+" was issued for it", target.Reason)
+	}
+
+	entries, err := foodShopService.ListOrderHistory()
+	assert.NoError(t, err)
+	assert.False(t, entries[0].Cancelled())
+
+	stock, err := foodShopService.ListStock()
+	assert.NoError(t, err)
+	assert.Equal(t, []_foodShopModel.StockLevel{{Code: "PURPLE", Remaining: 3}}, stock)
+}
+
+// slowOrderHistoryRepository pauses after every lookup, so a check on an
+// order and the change that follows it are far apart.
+type slowOrderHistoryRepository struct {
+	_orderHistoryRepository.OrderHistoryRepository
+}
+
+func (r slowOrderHistoryRepository) FindByOrderNo(orderNo int) (_orderHistoryModel.OrderHistoryEntry, error) {
+	entry, err := r.OrderHistoryRepository.FindByOrderNo(orderNo)
+	time.Sleep(20 * time.Millisecond)
+	return entry, err
+}
+
+func TestCancelOrder_ConcurrentTaxInvoice(t *testing.T) {
+	taxInvoiceRepository := _taxInvoiceRepository.NewTaxInvoiceRepositoryImpl("TI")
+	foodShopService := _foodShopService.NewFoodShopServiceImpl(
+		_foodShopRepository.NewFoodShopRepositoryDefault(),
+		slowOrderHistoryRepository{_orderHistoryRepository.NewOrderHistoryRepositoryImpl()},
+		_foodShopService.WithClock(domain.FixedClock(inventoryTestNow)),
+		_foodShopService.WithTaxPolicy(_foodShopModel.ThaiTaxPolicy(_foodShopModel.MenuPricingVATExclusive)),
+		_foodShopService.WithTaxInvoiceRepository(taxInvoiceRepository),
+	)
+	_, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{Items: map[string]int{"RED": 1}})
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	var cancelErr, issueErr error
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, cancelErr = foodShopService.CancelOrder(1)
+	}()
+	go func() {
+		defer wg.Done()
+		_, issueErr = foodShopService.IssueTaxInvoice(_taxInvoiceModel.IssueRequest{OrderNo: 1, Buyer: testBuyer()})
+	}()
+	wg.Wait()
+
+	assert.True(t, (cancelErr == nil) != (issueErr == nil), "exactly one of cancel and invoice succeeds: %v, %v", cancelErr, issueErr)
+
+	entries, err := foodShopService.ListOrderHistory()
+	assert.NoError(t, err)
+	_, findErr := taxInvoiceRepository.FindByOrderNo(1)
+	assert.False(t, entries[0].Cancelled() && findErr == nil, "the order is both cancelled and invoiced")
+}
+
+func TestCancelOrder_SideEffectsFail(t *testing.T) {
+	entry := _orderHistoryModel.OrderHistoryEntry{OrderNo: 1}
+	reservation := _inventoryModel.Reservation{OrderNo: 1, Items: map[_foodShopModel.MenuItemCode]int{"PURPLE": 2}}
+
+	t.Run("Release fails: the order is not cancelled", func(t *testing.T) {
+		orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+		inventoryRepositoryMock := new(_inventoryRepository.InventoryRepositoryMock)
+		orderHistoryRepositoryMock.Test(t)
+		inventoryRepositoryMock.Test(t)
+
+		orderHistoryRepositoryMock.On("FindByOrderNo", 1).Return(entry, nil).Once()
+		inventoryRepositoryMock.On("Release", 1).Return(_inventoryModel.Reservation{}, errors.New("inventory offline")).Once()
+
+		foodShopService := _foodShopService.NewFoodShopServiceImpl(
+			_foodShopRepository.NewFoodShopRepositoryDefault(),
+			orderHistoryRepositoryMock,
+			_foodShopService.WithInventoryRepository(inventoryRepositoryMock),
+		)
+
+		_, err := foodShopService.CancelOrder(1)
+		assert.ErrorContains(t, err, "inventory offline")
+
+		orderHistoryRepositoryMock.AssertNotCalled(t, "Cancel", mock.Anything, mock.Anything)
+		inventoryRepositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("Cancel fails: the stock is reserved again", func(t *testing.T) {
+		orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+		orderHistoryRepositoryMock.Test(t)
+		orderHistoryRepositoryMock.On("FindByOrderNo", 1).Return(entry, nil).Once()
+		orderHistoryRepositoryMock.On("Cancel", 1, mock.Anything).
+			Return(_orderHistoryModel.OrderHistoryEntry{}, errors.New("history offline")).
+			Once()
+
+		inventoryRepository := _inventoryRepository.NewInventoryRepositoryImpl([]_foodShopModel.StockLevel{{Code: "PURPLE", Remaining: 5}})
+		_, err := inventoryRepository.Reserve(reservation)
+		assert.NoError(t, err)
+
+		foodShopService := _foodShopService.NewFoodShopServiceImpl(
+			_foodShopRepository.NewFoodShopRepositoryDefault(),
+			orderHistoryRepositoryMock,
+			_foodShopService.WithInventoryRepository(inventoryRepository),
+		)
+
+		_, err = foodShopService.CancelOrder(1)
+		assert.ErrorContains(t, err, "history offline")
+
+		stock, err := foodShopService.ListStock()
+		assert.NoError(t, err)
+		assert.Equal(t, []_foodShopModel.StockLevel{{Code: "PURPLE", Remaining: 3}}, stock)
+
+		released, err := inventoryRepository.Release(1)
+		assert.NoError(t, err)
+		assert.Equal(t, reservation.Items, released.Items, "order #1 holds its stock again")
+
+		orderHistoryRepositoryMock.AssertExpectations(t)
+	})
+}
+
+func TestQuoteOrder_StockReleasedWhenCouponsFail(t *testing.T) {
+	couponRepositoryMock := new(_couponRepository.CouponRepositoryMock)
+	couponRepositoryMock.Test(t)
+	couponRepositoryMock.On("FindByCode", "SAVE10").
+		Return(_couponModel.Coupon{Code: "SAVE10", Type: _couponModel.CouponTypeFixed, Amount: domain.THB(10)}, nil).
+		Once()
+	couponRepositoryMock.On("CountRedemptions", "SAVE10", "").Return(0, 0, nil).Once()
+	couponRepositoryMock.On("Redeem", mock.Anything).
+		Return(&_foodShopException.CouponExhaustedError{Code: "SAVE10", Limit: 1}).
+		Once()
+
+	foodShopService, _ := newInventoryTestService(_foodShopService.WithCouponRepository(couponRepositoryMock))
+
+	_, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{
+		Items:   map[string]int{"PURPLE": 5},
+		Coupons: []string{"SAVE10"},
+	})
+	var exhausted *_foodShopException.CouponExhaustedError
+	assert.ErrorAs(t, err, &exhausted)
+
+	stock, err := foodShopService.ListStock()
+	assert.NoError(t, err)
+	assert.Equal(t, []_foodShopModel.StockLevel{{Code: "PURPLE", Remaining: 5}}, stock)
+
+	couponRepositoryMock.AssertExpectations(t)
+}
+
+func TestQuoteOrder_ConcurrentOrderNumbers(t *testing.T) {
+	foodShopService, _ := newInventoryTestService()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{Items: map[string]int{"PURPLE": 1}})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	entries, err := foodShopService.ListOrderHistory()
+	assert.NoError(t, err)
+	orderNos := make(map[int]bool, len(entries))
+	for _, entry := range entries {
+		orderNos[entry.OrderNo] = true
+	}
+	assert.Equal(t, map[int]bool{1: true, 2: true, 3: true, 4: true, 5: true}, orderNos)
+
+	for orderNo := 1; orderNo <= 5; orderNo++ {
+		_, err := foodShopService.CancelOrder(orderNo)
+		assert.NoError(t, err)
+	}
+	stock, err := foodShopService.ListStock()
+	assert.NoError(t, err)
+	assert.Equal(t, []_foodShopModel.StockLevel{{Code: "PURPLE", Remaining: 5}}, stock, "each order released what it reserved")
+}
+
+func TestInventoryRepository_Reserve(t *testing.T) {
+	repo := _inventoryRepository.NewInventoryRepositoryImpl([]_foodShopModel.StockLevel{
+		{Code: "ORANGE", Remaining: 2},
+		{Code: "PINK", Remaining: 1},
+	})
+
+	_, err := repo.Reserve(_inventoryModel.Reservation{
+		OrderNo: 1,
+		Items:   map[_foodShopModel.MenuItemCode]int{"ORANGE": 2, "PINK": 2},
+	})
+	var outOfStock *_foodShopException.OutOfStockError
+	assert.ErrorAs(t, err, &outOfStock)
+
+	levels, err := repo.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []_foodShopModel.StockLevel{{Code: "ORANGE", Remaining: 2}, {Code: "PINK", Remaining: 1}}, levels, "a failed reservation takes nothing")
+
+	levels, err = repo.Reserve(_inventoryModel.Reservation{
+		OrderNo: 1,
+		Items:   map[_foodShopModel.MenuItemCode]int{"ORANGE": 1, "RED": 9},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []_foodShopModel.StockLevel{{Code: "ORANGE", Remaining: 1}}, levels)
+
+	_, err = repo.Reserve(_inventoryModel.Reservation{OrderNo: 1, Items: map[_foodShopModel.MenuItemCode]int{"ORANGE": 1}})
+	assert.Error(t, err, "an order holds stock once")
+
+	released, err := repo.Release(1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[_foodShopModel.MenuItemCode]int{"ORANGE": 1}, released.Items)
+
+	released, err = repo.Release(1)
+	assert.NoError(t, err)
+	assert.Empty(t, released.Items)
+
+	levels, err = repo.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []_foodShopModel.StockLevel{{Code: "ORANGE", Remaining: 2}, {Code: "PINK", Remaining: 1}}, levels)
+}
+
+func TestInventoryRepository_ConcurrentReserve(t *testing.T) {
+	repo := _inventoryRepository.NewInventoryRepositoryImpl([]_foodShopModel.StockLevel{{Code: "ORANGE", Remaining: 10}})
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	reserved, soldOut := 0, 0
+	for i := 1; i <= 50; i++ {
+		wg.Add(1)
+		go func(orderNo int) {
+			defer wg.Done()
+			_, err := repo.Reserve(_inventoryModel.Reservation{
+				OrderNo: orderNo,
+				Items:   map[_foodShopModel.MenuItemCode]int{"ORANGE": 1},
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+			var outOfStock *_foodShopException.OutOfStockError
+			switch {
+			case err == nil:
+				reserved++
+			case errors.As(err, &outOfStock):
+				soldOut++
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 10, reserved)
+	assert.Equal(t, 40, soldOut)
+
+	levels, err := repo.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []_foodShopModel.StockLevel{{Code: "ORANGE", Remaining: 0}}, levels)
+}
@@ -0,0 +1,289 @@
+package tests
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopException "github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
+	_orderHistoryRepository "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
+)
+
+var menuAdminTestNow = time.Date(2026, time.October, 14, 12, 0, 0, 0, time.UTC)
+
+func TestAddMenuItem(t *testing.T) {
+	type tc struct {
+		label          string
+		item           _foodShopModel.MenuItem
+		expectedReason string
+		expectedDup    bool
+	}
+
+	brown := func() _foodShopModel.MenuItem {
+		return _foodShopModel.MenuItem{Code: "BROWN", Name: "Brown set", Price: domain.THB(65), Category: _foodShopModel.MenuCategorySet}
+	}
+	with := func(change func(*_foodShopModel.MenuItem)) _foodShopModel.MenuItem {
+		item := brown()
+		change(&item)
+		return item
+	}
+	codeReason := "code must be 1-16 upper-case letters, digits or underscores, starting with a letter"
+
+	cases := []tc{
+		{label: "Success", item: brown()},
+		{label: "Success: free item", item: with(func(it *_foodShopModel.MenuItem) { it.Code, it.Price = "WATER_REFILL", 0 })},
+		{label: "Fail: lower-case code", item: with(func(it *_foodShopModel.MenuItem) { it.Code = "Brown" }), expectedReason: codeReason},
+		{label: "Fail: code starts with a digit", item: with(func(it *_foodShopModel.MenuItem) { it.Code = "7UP" }), expectedReason: codeReason},
+		{label: "Fail: code too long", item: with(func(it *_foodShopModel.MenuItem) { it.Code = "ABCDEFGHIJKLMNOPQ" }), expectedReason: codeReason},
+		{label: "Fail: code with a space", item: with(func(it *_foodShopModel.MenuItem) { it.Code = "BROWN SET" }), expectedReason: codeReason},
+		{label: "Fail: blank name", item: with(func(it *_foodShopModel.MenuItem) { it.Name = "  " }), expectedReason: "name is required"},
+		{label: "Fail: negative price", item: with(func(it *_foodShopModel.MenuItem) { it.Price = -1 }), expectedReason: "price must be >= 0"},
+		{label: "Fail: unknown category", item: with(func(it *_foodShopModel.MenuItem) { it.Category = "SNACK" }), expectedReason: "unknown category SNACK"},
+		{
+			label:          "Fail: bad availability",
+			item:           with(func(it *_foodShopModel.MenuItem) { it.Availability = &_foodShopModel.Schedule{Days: []string{"XYZ"}} }),
+			expectedReason: `availability: invalid day "XYZ" (want MON..SUN)`,
+		},
+		{
+			label: "Fail: empty time window",
+			item: with(func(it *_foodShopModel.MenuItem) {
+				it.Availability = &_foodShopModel.Schedule{TimeFrom: "11:00", TimeTo: "11:00"}
+			}),
+			expectedReason: "availability: timeFrom and timeTo are both 11:00, so the window is never open",
+		},
+		{
+			label: "Fail: modifier minimum above maximum",
+			item: with(func(it *_foodShopModel.MenuItem) {
+				it.Modifiers = []_foodShopModel.ModifierGroup{{Code: "SIZE", MinSelections: 2, MaxSelections: 1}}
+			}),
+			expectedReason: "modifier group SIZE: minSelections is above maxSelections",
+		},
+		{
+			label: "Fail: option listed twice",
+			item: with(func(it *_foodShopModel.MenuItem) {
+				it.Modifiers = []_foodShopModel.ModifierGroup{
+					{Code: "SIZE", Options: []_foodShopModel.ModifierOption{{Code: "LARGE"}}},
+					{Code: "EXTRAS", Options: []_foodShopModel.ModifierOption{{Code: "LARGE"}}},
+				}
+			}),
+			expectedReason: "option LARGE is listed twice",
+		},
+		{label: "Fail: code in use", item: with(func(it *_foodShopModel.MenuItem) { it.Code = "RED" }), expectedDup: true},
+		{label: "Fail: code of a retired item", item: with(func(it *_foodShopModel.MenuItem) { it.Code = "PINK" }), expectedDup: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			repo := _foodShopRepository.NewFoodShopRepositoryDefault()
+			_, err := repo.RetireMenuItem("PINK", menuAdminTestNow)
+			assert.NoError(t, err)
+
+			added, err := repo.AddMenuItem(c.item)
+
+			switch {
+			case c.expectedDup:
+				var target *_foodShopException.DuplicateMenuItemError
+				if assert.ErrorAs(t, err, &target) {
+					assert.Equal(t, string(c.item.Code), target.Code)
+				}
+			case c.expectedReason != "":
+				var target *_foodShopException.InvalidMenuItemError
+				if assert.ErrorAs(t, err, &target) {
+					assert.Equal(t, c.expectedReason, target.Reason)
+				}
+				_, findErr := repo.FindMenuItemByCode(c.item.Code)
+				assert.Error(t, findErr)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, c.item, added)
+
+				found, err := repo.FindMenuItemByCode(c.item.Code)
+				assert.NoError(t, err)
+				assert.Equal(t, c.item, found)
+			}
+		})
+	}
+}
+
+func TestUpdateMenuItem(t *testing.T) {
+	price := func(baht int64) *domain.Money {
+		m := domain.THB(baht)
+		return &m
+	}
+	name := func(s string) *string { return &s }
+
+	type tc struct {
+		label         string
+		update        _foodShopModel.MenuItemUpdate
+		expectedName  string
+		expectedPrice domain.Money
+		expectedErr   func(t *testing.T, err error)
+	}
+
+	cases := []tc{
+		{
+			label:         "Success: price only",
+			update:        _foodShopModel.MenuItemUpdate{Code: "orange", Price: price(130)},
+			expectedName:  "Orange set",
+			expectedPrice: domain.THB(130),
+		},
+		{
+			label:         "Success: name only, trimmed",
+			update:        _foodShopModel.MenuItemUpdate{Code: "ORANGE", Name: name("  Orange pad thai ")},
+			expectedName:  "Orange pad thai",
+			expectedPrice: domain.THB(120),
+		},
+		{
+			label:  "Fail: negative price",
+			update: _foodShopModel.MenuItemUpdate{Code: "ORANGE", Price: price(-5)},
+			expectedErr: func(t *testing.T, err error) {
+				var target *_foodShopException.InvalidMenuItemError
+				assert.ErrorAs(t, err, &target)
+			},
+		},
+		{
+			label:  "Fail: blank name",
+			update: _foodShopModel.MenuItemUpdate{Code: "ORANGE", Name: name(" ")},
+			expectedErr: func(t *testing.T, err error) {
+				var target *_foodShopException.InvalidMenuItemError
+				assert.ErrorAs(t, err, &target)
+			},
+		},
+		{
+			label:  "Fail: unknown code",
+			update: _foodShopModel.MenuItemUpdate{Code: "NOPE", Price: price(1)},
+			expectedErr: func(t *testing.T, err error) {
+				var target _foodShopException.UnknownMenuItemError
+				assert.ErrorAs(t, err, &target)
+			},
+		},
+		{
+			label:  "Fail: retired",
+			update: _foodShopModel.MenuItemUpdate{Code: "PINK", Price: price(1)},
+			expectedErr: func(t *testing.T, err error) {
+				var target *_foodShopException.MenuItemRetiredError
+				if assert.ErrorAs(t, err, &target) {
+					assert.Equal(t, menuAdminTestNow, target.RetiredAt)
+				}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				_foodShopRepository.NewFoodShopRepositoryDefault(),
+				_orderHistoryRepository.NewOrderHistoryRepositoryImpl(),
+				_foodShopService.WithClock(domain.FixedClock(menuAdminTestNow)),
+			)
+			_, err := foodShopService.RetireMenuItem("PINK")
+			assert.NoError(t, err)
+
+			updated, err := foodShopService.UpdateMenuItem(c.update)
+			if c.expectedErr != nil {
+				c.expectedErr(t, err)
+
+				items, err := foodShopService.FilterMenuCatalog(_foodShopModel.MenuFilter{Tag: _foodShopModel.MenuTagBestseller})
+				assert.NoError(t, err)
+				assert.Empty(t, items[1].PriceChanges, "a rejected update leaves ORANGE alone")
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, c.expectedName, updated.Name)
+			assert.Equal(t, c.expectedPrice, updated.PriceAt(menuAdminTestNow).Price)
+
+			quote, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{Items: map[string]int{"ORANGE": 1}})
+			assert.NoError(t, err)
+			assert.Equal(t, c.expectedName, quote.Lines[0].Name)
+			assert.Equal(t, c.expectedPrice, quote.Lines[0].UnitPrice)
+		})
+	}
+}
+
+func TestRetireMenuItem(t *testing.T) {
+	foodShopService := _foodShopService.NewFoodShopServiceImpl(
+		_foodShopRepository.NewFoodShopRepositoryDefault(),
+		_orderHistoryRepository.NewOrderHistoryRepositoryImpl(),
+		_foodShopService.WithClock(domain.FixedClock(menuAdminTestNow)),
+	)
+
+	_, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{Items: map[string]int{"PINK": 2}})
+	assert.NoError(t, err)
+
+	retired, err := foodShopService.RetireMenuItem(" pink ")
+	assert.NoError(t, err)
+	assert.True(t, retired.Retired())
+	assert.Equal(t, menuAdminTestNow, retired.RetiredAt)
+
+	items, err := foodShopService.GetMenuCatalog()
+	assert.NoError(t, err)
+	assert.NotContains(t, menuCodes(items), _foodShopModel.MenuItemCode("PINK"))
+
+	items, err = foodShopService.FilterMenuCatalog(_foodShopModel.MenuFilter{Tag: _foodShopModel.MenuTagNew, IncludeRetired: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []_foodShopModel.MenuItemCode{"PINK", "LIMESODA", "ROTI"}, menuCodes(items))
+
+	_, err = foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{Items: map[string]int{"PINK": 1}})
+	var retiredErr *_foodShopException.MenuItemRetiredError
+	assert.ErrorAs(t, err, &retiredErr)
+
+	_, err = foodShopService.RetireMenuItem("PINK")
+	assert.ErrorAs(t, err, &retiredErr)
+
+	entries, err := foodShopService.ListOrderHistory()
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "Pink set", entries[0].Line[0].Name)
+		assert.Equal(t, domain.THB(160), entries[0].Line[0].LineTotal)
+	}
+
+	repo := _foodShopRepository.NewFoodShopRepositoryDefault()
+	_, err = repo.RetireMenuItem("PINK", menuAdminTestNow)
+	assert.NoError(t, err)
+	found, err := repo.FindMenuItemByCode("PINK")
+	assert.NoError(t, err, "retired items stay resolvable")
+	assert.Equal(t, "Pink set", found.Name)
+}
+
+func TestMenuAdmin_Concurrent(t *testing.T) {
+	repo := _foodShopRepository.NewFoodShopRepositoryDefault()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			_, err := repo.AddMenuItem(_foodShopModel.MenuItem{
+				Code:  _foodShopModel.MenuItemCode(fmt.Sprintf("NEW_%d", i)),
+				Name:  "New item",
+				Price: domain.THB(int64(i)),
+			})
+			assert.NoError(t, err)
+		}(i)
+		go func() {
+			defer wg.Done()
+			_, err := repo.ListMenuItems()
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	items, err := repo.ListMenuItems()
+	assert.NoError(t, err)
+	added := 0
+	for _, item := range items {
+		if strings.HasPrefix(string(item.Code), "NEW_") {
+			added++
+		}
+	}
+	assert.Equal(t, 20, added)
+}
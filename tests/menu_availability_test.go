@@ -0,0 +1,150 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopException "github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
+	_orderHistoryRepository "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
+)
+
+var bangkok = time.FixedZone("ICT", 7*60*60)
+
+// bkk is a time in Bangkok on a day of October 2026; the 17th is a
+// Saturday.
+func bkk(day, hour, minute int) time.Time {
+	return time.Date(2026, time.October, day, hour, minute, 0, 0, bangkok)
+}
+
+func TestScheduleNextActiveAfter(t *testing.T) {
+	type tc struct {
+		label        string
+		schedule     _foodShopModel.Schedule
+		at           time.Time
+		expected     time.Time
+		expectedNone bool
+	}
+
+	breakfast := _foodShopModel.Schedule{TimeFrom: "06:00", TimeTo: "11:00"}
+	weekend := _foodShopModel.Schedule{Days: []string{"SAT", "SUN"}}
+	lateNight := _foodShopModel.Schedule{Days: []string{"FRI"}, TimeFrom: "22:00", TimeTo: "02:00"}
+
+	cases := []tc{
+		{label: "Breakfast: before opening", schedule: breakfast, at: bkk(17, 5, 30), expected: bkk(17, 6, 0)},
+		{label: "Breakfast: after closing opens tomorrow", schedule: breakfast, at: bkk(17, 11, 0), expected: bkk(18, 6, 0)},
+		{label: "Weekend: from Wednesday", schedule: weekend, at: bkk(14, 9, 0), expected: bkk(17, 0, 0)},
+		{label: "Weekend: Sunday night opens next Saturday", schedule: weekend, at: bkk(19, 0, 0).Add(-time.Minute), expected: bkk(24, 0, 0)},
+		{label: "Late night: Friday after the early hours", schedule: lateNight, at: bkk(23, 3, 0), expected: bkk(23, 22, 0)},
+		{label: "Late night: early hours count for the day", schedule: lateNight, at: bkk(17, 3, 0), expected: bkk(23, 0, 0)},
+		{
+			label:    "Starts in the future",
+			schedule: _foodShopModel.Schedule{StartDate: "2026-12-01", Days: []string{"MON"}, TimeFrom: "10:00", TimeTo: "14:00"},
+			at:       bkk(17, 12, 0),
+			expected: time.Date(2026, time.December, 7, 10, 0, 0, 0, bangkok),
+		},
+		{label: "Ended", schedule: _foodShopModel.Schedule{EndDate: "2026-10-16"}, at: bkk(17, 12, 0), expectedNone: true},
+		{label: "Ends before the next opening", schedule: _foodShopModel.Schedule{EndDate: "2026-10-17", TimeFrom: "06:00", TimeTo: "11:00"}, at: bkk(17, 12, 0), expectedNone: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			got, ok, err := c.schedule.NextActiveAfter(c.at)
+			assert.NoError(t, err)
+			if c.expectedNone {
+				assert.False(t, ok)
+				return
+			}
+			assert.True(t, ok)
+			assert.True(t, c.expected.Equal(got), "expected %s, got %s", c.expected, got)
+		})
+	}
+}
+
+func TestQuoteOrder_Availability(t *testing.T) {
+	type tc struct {
+		label        string
+		now          time.Time
+		items        map[string]int
+		expectedNext time.Time
+		expectedMsg  string
+	}
+
+	cases := []tc{
+		{label: "Success: breakfast at 08:00", now: bkk(14, 8, 0), items: map[string]int{"SUNRISE": 1}},
+		{label: "Success: family set on Sunday", now: bkk(18, 20, 0), items: map[string]int{"FAMILY": 1, "RED": 1}},
+		{
+			label:        "Fail: breakfast at noon",
+			now:          bkk(14, 12, 0),
+			items:        map[string]int{"RED": 1, "SUNRISE": 1},
+			expectedNext: bkk(15, 6, 0),
+			expectedMsg:  "Error: SUNRISE is not available right now (06:00-11:00 (Asia/Bangkok)); next available Thu 2026-10-15 06:00",
+		},
+		{
+			label:        "Fail: family set on Wednesday",
+			now:          bkk(14, 12, 0),
+			items:        map[string]int{"FAMILY": 1},
+			expectedNext: bkk(17, 0, 0),
+			expectedMsg:  "Error: FAMILY is not available right now (SAT,SUN (Asia/Bangkok)); next available Sat 2026-10-17 00:00",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			orderHistoryRepository := _orderHistoryRepository.NewOrderHistoryRepositoryImpl()
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				_foodShopRepository.NewFoodShopRepositoryDefault(),
+				orderHistoryRepository,
+				_foodShopService.WithClock(domain.FixedClock(c.now)),
+			)
+
+			_, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{Items: c.items})
+
+			count, countErr := orderHistoryRepository.Count()
+			assert.NoError(t, countErr)
+
+			if c.expectedMsg == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, count)
+				return
+			}
+
+			var target *_foodShopException.MenuItemUnavailableError
+			if assert.ErrorAs(t, err, &target) {
+				assert.True(t, c.expectedNext.Equal(target.NextAvailable), "expected %s, got %s", c.expectedNext, target.NextAvailable)
+				assert.Equal(t, c.expectedMsg, err.Error())
+			}
+			assert.Equal(t, 0, count)
+		})
+	}
+}
+
+func TestFilterMenuAvailability(t *testing.T) {
+	foodShopService := _foodShopService.NewFoodShopServiceImpl(
+		_foodShopRepository.NewFoodShopRepositoryDefault(),
+		_orderHistoryRepository.NewOrderHistoryRepositoryImpl(),
+		_foodShopService.WithClock(domain.FixedClock(bkk(14, 12, 0))),
+	)
+
+	statuses, err := foodShopService.FilterMenuAvailability(_foodShopModel.MenuFilter{Category: _foodShopModel.MenuCategorySet})
+	assert.NoError(t, err)
+
+	unavailable := make(map[_foodShopModel.MenuItemCode]time.Time)
+	for _, status := range statuses {
+		if !status.Available {
+			unavailable[status.Code] = status.NextAvailable
+		} else {
+			assert.True(t, status.NextAvailable.IsZero())
+		}
+	}
+
+	assert.Len(t, statuses, 9)
+	assert.Len(t, unavailable, 2)
+	assert.True(t, bkk(15, 6, 0).Equal(unavailable["SUNRISE"]))
+	assert.True(t, bkk(17, 0, 0).Equal(unavailable["FAMILY"]))
+}
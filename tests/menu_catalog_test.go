@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
+	_orderHistoryRepository "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
+)
+
+func menuCodes(items []_foodShopModel.MenuItem) []_foodShopModel.MenuItemCode {
+	codes := make([]_foodShopModel.MenuItemCode, 0, len(items))
+	for _, it := range items {
+		codes = append(codes, it.Code)
+	}
+	return codes
+}
+
+func TestFilterMenuCatalog(t *testing.T) {
+	type tc struct {
+		label    string
+		filter   _foodShopModel.MenuFilter
+		expected []_foodShopModel.MenuItemCode
+	}
+
+	cases := []tc{
+		{
+			label:    "No filter: by category, then display order",
+			expected: []_foodShopModel.MenuItemCode{"RED", "GREEN", "BLUE", "YELLOW", "PINK", "PURPLE", "ORANGE", "SUNRISE", "FAMILY", "THAITEA", "LIMESODA", "MANGO", "ROTI"},
+		},
+		{
+			label:    "Drinks",
+			filter:   _foodShopModel.MenuFilter{Category: _foodShopModel.MenuCategoryDrink},
+			expected: []_foodShopModel.MenuItemCode{"THAITEA", "LIMESODA"},
+		},
+		{
+			label:    "New across categories",
+			filter:   _foodShopModel.MenuFilter{Tag: _foodShopModel.MenuTagNew},
+			expected: []_foodShopModel.MenuItemCode{"PINK", "LIMESODA", "ROTI"},
+		},
+		{
+			label:    "Bestseller sets",
+			filter:   _foodShopModel.MenuFilter{Category: _foodShopModel.MenuCategorySet, Tag: _foodShopModel.MenuTagBestseller},
+			expected: []_foodShopModel.MenuItemCode{"RED", "ORANGE"},
+		},
+		{
+			label:    "Spicy desserts: none",
+			filter:   _foodShopModel.MenuFilter{Category: _foodShopModel.MenuCategoryDessert, Tag: _foodShopModel.MenuTagSpicy},
+			expected: []_foodShopModel.MenuItemCode{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				_foodShopRepository.NewFoodShopRepositoryDefault(),
+				_orderHistoryRepository.NewOrderHistoryRepositoryImpl(),
+			)
+
+			items, err := foodShopService.FilterMenuCatalog(c.filter)
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, menuCodes(items))
+		})
+	}
+}
+
+func TestListMenuItems_OrderAndCopies(t *testing.T) {
+	repo := _foodShopRepository.NewFoodShopRepositoryImpl(map[_foodShopModel.MenuItemCode]_foodShopModel.MenuItem{
+		"B":    {Code: "B", Category: _foodShopModel.MenuCategoryDessert},
+		"A":    {Code: "A", Category: _foodShopModel.MenuCategoryDessert},
+		"Z":    {Code: "Z", Category: _foodShopModel.MenuCategoryDessert, DisplayOrder: -1},
+		"X":    {Code: "X"},
+		"TEA":  {Code: "TEA", Category: _foodShopModel.MenuCategoryDrink, Tags: []_foodShopModel.MenuTag{_foodShopModel.MenuTagNew}},
+		"RICE": {Code: "RICE", Category: _foodShopModel.MenuCategorySet, DisplayOrder: 99},
+	}, nil)
+
+	items, err := repo.ListMenuItems()
+	assert.NoError(t, err)
+	assert.Equal(t, []_foodShopModel.MenuItemCode{"RICE", "TEA", "Z", "A", "B", "X"}, menuCodes(items))
+
+	items[1].Tags[0] = _foodShopModel.MenuTagSpicy
+	tea, err := repo.FindMenuItemByCode("TEA")
+	assert.NoError(t, err)
+	assert.Equal(t, []_foodShopModel.MenuTag{_foodShopModel.MenuTagNew}, tea.Tags)
+}
+
+func TestParseMenuFilter(t *testing.T) {
+	cases := []struct {
+		in          string
+		expected    _foodShopModel.MenuFilter
+		expectedErr bool
+	}{
+		{in: ""},
+		{in: "drink", expected: _foodShopModel.MenuFilter{Category: _foodShopModel.MenuCategoryDrink}},
+		{in: " Spicy  SET ", expected: _foodShopModel.MenuFilter{Category: _foodShopModel.MenuCategorySet, Tag: _foodShopModel.MenuTagSpicy}},
+		{in: "bestseller", expected: _foodShopModel.MenuFilter{Tag: _foodShopModel.MenuTagBestseller}},
+		{in: "vegan", expectedErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.in, func(t *testing.T) {
+			got, err := _foodShopModel.ParseMenuFilter(c.in)
+			if c.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, got)
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopException "github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
+	_orderHistoryRepository "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
+)
+
+// testClock is a clock the test can move forward.
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time { return c.now }
+
+func TestMenuItemPriceAt(t *testing.T) {
+	item := _foodShopModel.MenuItem{
+		Code:  "ORANGE",
+		Price: domain.THB(120),
+		PriceChanges: []_foodShopModel.PriceVersion{
+			{Version: 2, Price: domain.THB(130), EffectiveFrom: bkk(1, 0, 0).AddDate(0, 1, 0)},
+			{Version: 3, Price: domain.THB(125), EffectiveFrom: bkk(1, 0, 0).AddDate(0, 3, 0)},
+		},
+	}
+
+	cases := []struct {
+		label           string
+		at              time.Time
+		expectedVersion int
+		expectedPrice   domain.Money
+		expectedNext    int
+	}{
+		{label: "Long before any change", at: time.Date(2020, time.March, 3, 12, 0, 0, 0, bangkok), expectedVersion: 1, expectedPrice: domain.THB(120), expectedNext: 2},
+		{label: "A minute before v2", at: bkk(31, 23, 59), expectedVersion: 1, expectedPrice: domain.THB(120), expectedNext: 2},
+		{label: "The moment v2 takes effect", at: bkk(1, 0, 0).AddDate(0, 1, 0), expectedVersion: 2, expectedPrice: domain.THB(130), expectedNext: 3},
+		{label: "After the last change", at: bkk(1, 0, 0).AddDate(1, 0, 0), expectedVersion: 3, expectedPrice: domain.THB(125)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			price := item.PriceAt(c.at)
+			assert.Equal(t, c.expectedVersion, price.Version)
+			assert.Equal(t, c.expectedPrice, price.Price)
+
+			next, ok := item.NextPriceAfter(c.at)
+			assert.Equal(t, c.expectedNext != 0, ok)
+			assert.Equal(t, c.expectedNext, next.Version)
+		})
+	}
+}
+
+func TestScheduledPriceChange(t *testing.T) {
+	clock := &testClock{now: bkk(17, 12, 0)}
+	orderHistoryRepository := _orderHistoryRepository.NewOrderHistoryRepositoryImpl()
+	foodShopService := _foodShopService.NewFoodShopServiceImpl(
+		_foodShopRepository.NewFoodShopRepositoryDefault(),
+		orderHistoryRepository,
+		_foodShopService.WithClock(clock),
+	)
+
+	november := time.Date(2026, time.November, 1, 0, 0, 0, 0, bangkok)
+	newPrice := domain.THB(130)
+	_, err := foodShopService.UpdateMenuItem(_foodShopModel.MenuItemUpdate{Code: "ORANGE", Price: &newPrice, EffectiveFrom: november})
+	assert.NoError(t, err)
+
+	statuses, err := foodShopService.FilterMenuAvailability(_foodShopModel.MenuFilter{Tag: _foodShopModel.MenuTagBestseller})
+	assert.NoError(t, err)
+	orange := statuses[1]
+	assert.Equal(t, _foodShopModel.MenuItemCode("ORANGE"), orange.Code)
+	assert.Equal(t, domain.THB(120), orange.CurrentPrice.Price)
+	if assert.NotNil(t, orange.NextPrice) {
+		assert.Equal(t, _foodShopModel.PriceVersion{Version: 2, Price: newPrice, EffectiveFrom: november}, *orange.NextPrice)
+	}
+
+	quote, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{Items: map[string]int{"ORANGE": 2}})
+	assert.NoError(t, err)
+	assert.Equal(t, _foodShopModel.PriceVersion{Version: 1, Price: domain.THB(120)}, quote.Lines[0].MenuPrice)
+	assert.Equal(t, domain.THB(228), quote.Total)
+
+	clock.now = november
+	quote, err = foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{Items: map[string]int{"ORANGE": 2}})
+	assert.NoError(t, err)
+	assert.Equal(t, _foodShopModel.PriceVersion{Version: 2, Price: newPrice, EffectiveFrom: november}, quote.Lines[0].MenuPrice)
+	assert.Equal(t, domain.THB(130), quote.Lines[0].UnitPrice)
+	assert.Equal(t, domain.THB(247), quote.Total, "the pair discount works on the new price")
+
+	entries, err := orderHistoryRepository.List()
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, 1, entries[0].Line[0].MenuPrice.Version)
+		assert.Equal(t, 2, entries[1].Line[0].MenuPrice.Version)
+	}
+
+	history, err := foodShopService.GetPriceHistory(" orange ")
+	assert.NoError(t, err)
+	assert.Equal(t, []_foodShopModel.PriceVersion{
+		{Version: 1, Price: domain.THB(120)},
+		{Version: 2, Price: newPrice, EffectiveFrom: november},
+	}, history)
+
+	onMarch3, err := foodShopService.GetPriceAt("ORANGE", time.Date(2026, time.March, 3, 12, 0, 0, 0, bangkok))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, onMarch3.Version)
+
+	onNov3, err := foodShopService.GetPriceAt("ORANGE", november.AddDate(0, 0, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, domain.THB(130), onNov3.Price)
+}
+
+func TestUpdateMenuItem_PriceVersionsFail(t *testing.T) {
+	newPrice := domain.THB(130)
+
+	cases := []struct {
+		label          string
+		update         _foodShopModel.MenuItemUpdate
+		expectedReason string
+	}{
+		{
+			label:          "In the past",
+			update:         _foodShopModel.MenuItemUpdate{Code: "ORANGE", Price: &newPrice, EffectiveFrom: bkk(17, 11, 59)},
+			expectedReason: "effectiveFrom is in the past",
+		},
+		{
+			label:          "Before the scheduled change",
+			update:         _foodShopModel.MenuItemUpdate{Code: "ORANGE", Price: &newPrice, EffectiveFrom: bkk(20, 0, 0)},
+			expectedReason: "price changes must take effect after the latest one (2026-11-01 00:00)",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				_foodShopRepository.NewFoodShopRepositoryDefault(),
+				_orderHistoryRepository.NewOrderHistoryRepositoryImpl(),
+				_foodShopService.WithClock(domain.FixedClock(bkk(17, 12, 0))),
+			)
+			scheduled := domain.THB(125)
+			_, err := foodShopService.UpdateMenuItem(_foodShopModel.MenuItemUpdate{
+				Code:          "ORANGE",
+				Price:         &scheduled,
+				EffectiveFrom: time.Date(2026, time.November, 1, 0, 0, 0, 0, bangkok),
+			})
+			assert.NoError(t, err)
+
+			_, err = foodShopService.UpdateMenuItem(c.update)
+			var target *_foodShopException.InvalidMenuItemError
+			if assert.ErrorAs(t, err, &target) {
+				assert.Equal(t, c.expectedReason, target.Reason)
+			}
+
+			history, err := foodShopService.GetPriceHistory("ORANGE")
+			assert.NoError(t, err)
+			assert.Len(t, history, 2)
+		})
+	}
+
+	repo := _foodShopRepository.NewFoodShopRepositoryDefault()
+	_, err := repo.UpdateMenuItem(_foodShopModel.MenuItemUpdate{Code: "ORANGE", Price: &newPrice})
+	var target *_foodShopException.InvalidMenuItemError
+	if assert.ErrorAs(t, err, &target) {
+		assert.Equal(t, "a new price needs effectiveFrom", target.Reason)
+	}
+}
+
+func TestAddMenuItem_PriceChanges(t *testing.T) {
+	repo := _foodShopRepository.NewFoodShopRepositoryDefault()
+
+	added, err := repo.AddMenuItem(_foodShopModel.MenuItem{
+		Code:  "BROWN",
+		Name:  "Brown set",
+		Price: domain.THB(65),
+		PriceChanges: []_foodShopModel.PriceVersion{
+			{Price: domain.THB(69), EffectiveFrom: bkk(20, 0, 0)},
+			{Version: 9, Price: domain.THB(72), EffectiveFrom: bkk(27, 0, 0)},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, []int{added.Prices()[0].Version, added.Prices()[1].Version, added.Prices()[2].Version})
+
+	_, err = repo.AddMenuItem(_foodShopModel.MenuItem{
+		Code:  "GREY",
+		Name:  "Grey set",
+		Price: domain.THB(65),
+		PriceChanges: []_foodShopModel.PriceVersion{
+			{Price: domain.THB(72), EffectiveFrom: bkk(27, 0, 0)},
+			{Price: domain.THB(69), EffectiveFrom: bkk(20, 0, 0)},
+		},
+	})
+	var target *_foodShopException.InvalidMenuItemError
+	assert.ErrorAs(t, err, &target)
+
+	foodShopService := _foodShopService.NewFoodShopServiceImpl(
+		repo,
+		_orderHistoryRepository.NewOrderHistoryRepositoryImpl(),
+		_foodShopService.WithClock(domain.FixedClock(bkk(17, 12, 0))),
+	)
+	_, err = foodShopService.AddMenuItem(_foodShopModel.MenuItem{
+		Code:  "TEAL",
+		Name:  "Teal set",
+		Price: domain.THB(65),
+		PriceChanges: []_foodShopModel.PriceVersion{
+			{Price: domain.THB(60), EffectiveFrom: bkk(10, 0, 0)},
+			{Price: domain.THB(69), EffectiveFrom: bkk(20, 0, 0)},
+		},
+	})
+	if assert.ErrorAs(t, err, &target) {
+		assert.Equal(t, "effectiveFrom is in the past", target.Reason)
+	}
+	_, err = repo.FindMenuItemByCode("TEAL")
+	assert.Error(t, err, "a backdated price keeps the item off the menu")
+}
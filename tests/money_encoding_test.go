@@ -0,0 +1,137 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
+	_orderHistoryRepository "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
+)
+
+func TestParseMoney(t *testing.T) {
+	cases := []struct {
+		in       string
+		expected domain.Money
+	}{
+		{in: "50", expected: domain.THB(50)},
+		{in: "50.5", expected: satang(5050)},
+		{in: "50.05", expected: satang(5005)},
+		{in: " 1250.00 THB ", expected: domain.THB(1250)},
+		{in: "-0.25", expected: satang(-25)},
+		{in: "0", expected: 0},
+		{in: "92233720368547758.07", expected: domain.Money(9223372036854775807)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.in, func(t *testing.T) {
+			got, err := domain.ParseMoney(c.in)
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, got)
+
+			again, err := domain.ParseMoney(got.String())
+			assert.NoError(t, err)
+			assert.Equal(t, got, again)
+		})
+	}
+}
+
+func TestParseMoney_Invalid(t *testing.T) {
+	for _, in := range []string{"", "THB", "50.", ".5", "50.001", "1,000", "+5", "5e2", "12.3.4", "0x10", "- 5", "92233720368547758.08", "99999999999999999999"} {
+		t.Run(in, func(t *testing.T) {
+			_, err := domain.ParseMoney(in)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestMoney_Encoding(t *testing.T) {
+	cases := []struct {
+		label string
+		in    domain.Money
+		text  string
+	}{
+		{label: "Whole baht", in: domain.THB(50), text: "50.00"},
+		{label: "Satang", in: satang(4788), text: "47.88"},
+		{label: "Negative under one baht keeps its sign", in: satang(-11), text: "-0.11"},
+		{label: "Zero", in: 0, text: "0.00"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			text, err := c.in.MarshalText()
+			assert.NoError(t, err)
+			assert.Equal(t, c.text, string(text))
+			assert.Equal(t, c.text+" THB", c.in.String())
+
+			data, err := json.Marshal(c.in)
+			assert.NoError(t, err)
+			assert.Equal(t, `"`+c.text+`"`, string(data))
+
+			var decoded domain.Money
+			assert.NoError(t, json.Unmarshal(data, &decoded))
+			assert.Equal(t, c.in, decoded)
+		})
+	}
+
+	var fromNumber domain.Money
+	assert.NoError(t, json.Unmarshal([]byte(`50.5`), &fromNumber))
+	assert.Equal(t, satang(5050), fromNumber)
+
+	withNull := struct {
+		Price domain.Money `json:"price"`
+	}{Price: domain.THB(45)}
+	assert.NoError(t, json.Unmarshal([]byte(`{"price":null}`), &withNull))
+	assert.Equal(t, domain.THB(45), withNull.Price, "null leaves the amount alone")
+
+	var strict domain.Money
+	assert.Error(t, json.Unmarshal([]byte(`"50.005"`), &strict))
+
+	keyed, err := json.Marshal(map[domain.Money]int{domain.THB(1): 1})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"1.00":1}`, string(keyed))
+}
+
+func TestQuoteOrder_JSONRoundTrip(t *testing.T) {
+	foodShopRepositoryMock := new(_foodShopRepository.FoodShopRepositoryMock)
+	orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+
+	foodShopRepositoryMock.Test(t)
+	orderHistoryRepositoryMock.Test(t)
+
+	for code, item := range _foodShopRepository.DefaultMenu() {
+		foodShopRepositoryMock.On("FindMenuItemByCode", code).Return(item, nil).Maybe()
+	}
+	expectDefaultPromotions(foodShopRepositoryMock)
+	orderHistoryRepositoryMock.On("Add", mock.Anything).Return(nil).Once()
+
+	foodShopService := _foodShopService.NewFoodShopServiceImpl(
+		foodShopRepositoryMock,
+		orderHistoryRepositoryMock,
+		_foodShopService.WithMemberRepository(testMemberRepository()),
+		_foodShopService.WithRoundingStrategy(_foodShopModel.AccountingRoundingStrategy()),
+	)
+
+	quote, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{
+		Items:    map[string]int{"GREEN": 2, "RED": 1},
+		MemberID: goldMemberID,
+	})
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(quote)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"Total":"113.40"`)
+	assert.Contains(t, string(data), `"cashUnit":"0.25"`)
+
+	var decoded _foodShopModel.OrderQuote
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, quote, decoded)
+
+	foodShopRepositoryMock.AssertExpectations(t)
+	orderHistoryRepositoryMock.AssertExpectations(t)
+}
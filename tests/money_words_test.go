@@ -0,0 +1,65 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+)
+
+func TestMoney_Words(t *testing.T) {
+	cases := []struct {
+		label   string
+		in      domain.Money
+		thai    string
+		english string
+	}{
+		{label: "Zero", in: 0, thai: "ศูนย์บาทถ้วน", english: "Zero baht only"},
+		{label: "One baht", in: domain.THB(1), thai: "หนึ่งบาทถ้วน", english: "One baht only"},
+		{label: "Ten", in: domain.THB(10), thai: "สิบบาทถ้วน", english: "Ten baht only"},
+		{label: "Eleven uses เอ็ด", in: domain.THB(11), thai: "สิบเอ็ดบาทถ้วน", english: "Eleven baht only"},
+		{label: "Twenty uses ยี่", in: domain.THB(20), thai: "ยี่สิบบาทถ้วน", english: "Twenty baht only"},
+		{label: "Twenty-one", in: domain.THB(21), thai: "ยี่สิบเอ็ดบาทถ้วน", english: "Twenty-one baht only"},
+		{label: "101 uses เอ็ด", in: domain.THB(101), thai: "หนึ่งร้อยเอ็ดบาทถ้วน", english: "One hundred one baht only"},
+		{label: "126", in: domain.THB(126), thai: "หนึ่งร้อยยี่สิบหกบาทถ้วน", english: "One hundred twenty-six baht only"},
+		{label: "68.40", in: satang(6840), thai: "หกสิบแปดบาทสี่สิบสตางค์", english: "Sixty-eight baht and forty satang"},
+		{label: "Satang only", in: satang(50), thai: "ห้าสิบสตางค์", english: "Fifty satang"},
+		{label: "One satang", in: satang(1), thai: "หนึ่งสตางค์", english: "One satang"},
+		{label: "21 satang", in: satang(21), thai: "ยี่สิบเอ็ดสตางค์", english: "Twenty-one satang"},
+		{label: "1.01", in: satang(101), thai: "หนึ่งบาทหนึ่งสตางค์", english: "One baht and one satang"},
+		{label: "Zeros inside", in: domain.THB(1005), thai: "หนึ่งพันห้าบาทถ้วน", english: "One thousand five baht only"},
+		{label: "One hundred thousand", in: domain.THB(100_000), thai: "หนึ่งแสนบาทถ้วน", english: "One hundred thousand baht only"},
+		{label: "One million", in: domain.THB(1_000_000), thai: "หนึ่งล้านบาทถ้วน", english: "One million baht only"},
+		{label: "Million and one uses เอ็ด", in: domain.THB(1_000_001), thai: "หนึ่งล้านเอ็ดบาทถ้วน", english: "One million one baht only"},
+		{label: "Eleven million", in: domain.THB(11_000_000), thai: "สิบเอ็ดล้านบาทถ้วน", english: "Eleven million baht only"},
+		{label: "Twenty-one million", in: domain.THB(21_000_000), thai: "ยี่สิบเอ็ดล้านบาทถ้วน", english: "Twenty-one million baht only"},
+		{label: "Million million", in: domain.THB(1_000_000_000_000), thai: "หนึ่งล้านล้านบาทถ้วน", english: "One trillion baht only"},
+		{
+			label:   "Mixed large amount",
+			in:      satang(123_456_789_12),
+			thai:    "หนึ่งร้อยยี่สิบสามล้านสี่แสนห้าหมื่นหกพันเจ็ดร้อยแปดสิบเก้าบาทสิบสองสตางค์",
+			english: "One hundred twenty-three million four hundred fifty-six thousand seven hundred eighty-nine baht and twelve satang",
+		},
+		{
+			label:   "Largest amount",
+			in:      domain.Money(math.MaxInt64),
+			thai:    "เก้าหมื่นสองพันสองร้อยสามสิบสามล้านเจ็ดแสนสองหมื่นสามร้อยหกสิบแปดล้านห้าแสนสี่หมื่นเจ็ดพันเจ็ดร้อยห้าสิบแปดบาทเจ็ดสตางค์",
+			english: "Ninety-two quadrillion two hundred thirty-three trillion seven hundred twenty billion three hundred sixty-eight million five hundred forty-seven thousand seven hundred fifty-eight baht and seven satang",
+		},
+		{label: "Negative", in: satang(-11), thai: "ลบสิบเอ็ดสตางค์", english: "Minus eleven satang"},
+		{label: "Negative whole", in: domain.THB(-2), thai: "ลบสองบาทถ้วน", english: "Minus two baht only"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			assert.Equal(t, c.thai, c.in.ThaiWords())
+			assert.Equal(t, c.english, c.in.EnglishWords())
+		})
+	}
+
+	assert.Equal(t,
+		"ลบเก้าหมื่นสองพันสองร้อยสามสิบสามล้านเจ็ดแสนสองหมื่นสามร้อยหกสิบแปดล้านห้าแสนสี่หมื่นเจ็ดพันเจ็ดร้อยห้าสิบแปดบาทแปดสตางค์",
+		domain.Money(math.MinInt64).ThaiWords())
+}
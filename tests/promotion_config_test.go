@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopException "github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
+	_orderHistoryRepository "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
+)
+
+func TestLoadPromotionsFile_MatchesDefaults(t *testing.T) {
+	promotions, err := _foodShopRepository.LoadPromotionsFile("../config/promotions.json")
+	assert.NoError(t, err)
+	assert.Equal(t, _foodShopRepository.DefaultPromotions(), promotions)
+}
+
+func TestParsePromotions_Invalid(t *testing.T) {
+	cases := []struct {
+		label string
+		data  string
+	}{
+		{label: "Fail: not JSON", data: `{`},
+		{label: "Fail: unknown field", data: `[{"code":"X","type":"MEMBER","percent":10}]`},
+		{label: "Fail: missing code", data: `[{"type":"MEMBER"}]`},
+		{label: "Fail: unknown type", data: `[{"code":"X","type":"LOTTERY"}]`},
+		{label: "Fail: pair without bundle size", data: `[{"code":"X","type":"PAIR","eligibleCodes":["RED"],"params":{"discountPercent":5}}]`},
+		{label: "Fail: percent over 100", data: `[{"code":"X","type":"PAIR","eligibleCodes":["RED"],"params":{"discountPercent":101,"bundleSize":2}}]`},
+		{label: "Fail: member with its own rate", data: `[{"code":"X","type":"MEMBER","params":{"discountPercent":10}}]`},
+		{label: "Fail: duplicate code", data: `[{"code":"X","type":"MEMBER"},{"code":"x","type":"MEMBER"}]`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			_, err := _foodShopRepository.ParsePromotions([]byte(c.data))
+
+			var target *_foodShopException.InvalidPromotionConfigError
+			assert.ErrorAs(t, err, &target)
+		})
+	}
+}
+
+func TestQuoteOrder_PromotionsFromRepository(t *testing.T) {
+	promotions, err := _foodShopRepository.ParsePromotions([]byte(`[
+		{"code":"MEMBER","type":"MEMBER","title":"Member tiers","priority":1},
+		{"code":"TRIO","type":"PAIR","title":"Blue trio 10%","priority":2,"eligibleCodes":["blue"],"params":{"discountPercent":10,"bundleSize":3}}
+	]`))
+	assert.NoError(t, err)
+
+	foodShopRepositoryMock := new(_foodShopRepository.FoodShopRepositoryMock)
+	orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+
+	foodShopRepositoryMock.Test(t)
+	orderHistoryRepositoryMock.Test(t)
+
+	foodShopRepositoryMock.
+		On("FindMenuItemByCode", _foodShopModel.MenuItemCode("BLUE")).
+		Return(_foodShopModel.MenuItem{Code: "BLUE", Name: "Blue set", Price: domain.THB(30)}, nil).
+		Once()
+	foodShopRepositoryMock.On("ListPromotions").Return(promotions, nil).Once()
+	orderHistoryRepositoryMock.On("Add", mock.Anything).Return(nil).Once()
+
+	foodShopService := _foodShopService.NewFoodShopServiceImpl(
+		foodShopRepositoryMock,
+		orderHistoryRepositoryMock,
+		_foodShopService.WithMemberRepository(testMemberRepository()),
+	)
+
+	res, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{
+		Items:    map[string]int{"BLUE": 3},
+		MemberID: "P001",
+	})
+	assert.NoError(t, err)
+
+	// Platinum member runs first (priority 1): 15% of 90 = 13.50, then 10% of the 90 trio = 9.
+	assert.Equal(t, domain.THB(90), res.Subtotal)
+	assert.Equal(t, satang(1350), res.MemberDiscount)
+	assert.Equal(t, domain.THB(9), res.PairDiscount)
+	assert.Equal(t, satang(6750), res.Total)
+
+	foodShopRepositoryMock.AssertExpectations(t)
+	orderHistoryRepositoryMock.AssertExpectations(t)
+}
@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopPromotion "github.com/TewApirat/food-shop/pkg/foodShop/promotion"
+	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
+	_orderHistoryRepository "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
+)
+
+func TestQuoteOrder_PromotionPipeline(t *testing.T) {
+	type tc struct {
+		label string
+		rules []_foodShopPromotion.PromotionRule
+		in    _foodShopModel.PurchasingRequest
+
+		expectedPairDiscount   domain.Money
+		expectedMemberDiscount domain.Money
+		expectedTotal          domain.Money
+	}
+
+	pair := _foodShopPromotion.NewPairDiscountRule("PAIR", _foodShopModel.DefaultPairDiscountPolicy())
+	member := _foodShopPromotion.NewMemberDiscountRule("MEMBER")
+
+	cases := []tc{
+		{
+			label: "Member first: member 10% of 80, then pair 5% of the bundle",
+			rules: []_foodShopPromotion.PromotionRule{member, pair},
+			in: _foodShopModel.PurchasingRequest{
+				Items:    map[string]int{"GREEN": 2},
+				MemberID: goldMemberID,
+			},
+			expectedPairDiscount:   domain.THB(4),
+			expectedMemberDiscount: domain.THB(8),
+			expectedTotal:          domain.THB(68),
+		},
+		{
+			label: "Pair retired: GREEN(2) pays full price",
+			rules: []_foodShopPromotion.PromotionRule{member},
+			in: _foodShopModel.PurchasingRequest{
+				Items: map[string]int{"GREEN": 2},
+			},
+			expectedPairDiscount:   domain.THB(0),
+			expectedMemberDiscount: domain.THB(0),
+			expectedTotal:          domain.THB(80),
+		},
+		{
+			label: "Custom pair policy: BLUE bundle of 3 at 10%",
+			rules: []_foodShopPromotion.PromotionRule{
+				_foodShopPromotion.NewPairDiscountRule("BLUE3", _foodShopModel.PairDiscountPolicy{
+					EligibleCodes: map[_foodShopModel.MenuItemCode]bool{"BLUE": true},
+					DiscountRate:  domain.PercentRate(10),
+					BundleSize:    3,
+				}),
+			},
+			in: _foodShopModel.PurchasingRequest{
+				Items: map[string]int{"BLUE": 2},
+			},
+			expectedPairDiscount:   domain.THB(0),
+			expectedMemberDiscount: domain.THB(0),
+			expectedTotal:          domain.THB(60),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			foodShopRepositoryMock := new(_foodShopRepository.FoodShopRepositoryMock)
+			orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+
+			foodShopRepositoryMock.Test(t)
+			orderHistoryRepositoryMock.Test(t)
+
+			menu := _foodShopRepository.DefaultMenu()
+			for rawCode := range c.in.Items {
+				code := _foodShopModel.MenuItemCode(rawCode)
+				foodShopRepositoryMock.On("FindMenuItemByCode", code).Return(menu[code], nil).Once()
+			}
+			orderHistoryRepositoryMock.On("Add", mock.Anything).Return(nil).Once()
+
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				foodShopRepositoryMock,
+				orderHistoryRepositoryMock,
+				_foodShopService.WithPromotionRules(c.rules...),
+				_foodShopService.WithMemberRepository(testMemberRepository()),
+			)
+
+			res, err := foodShopService.QuoteOrder(c.in)
+			assert.NoError(t, err)
+
+			assert.Equal(t, c.expectedPairDiscount, res.PairDiscount)
+			assert.Equal(t, c.expectedMemberDiscount, res.MemberDiscount)
+			assert.Equal(t, c.expectedTotal, res.Total)
+
+			foodShopRepositoryMock.AssertExpectations(t)
+			orderHistoryRepositoryMock.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
+	_orderHistoryModel "github.com/TewApirat/food-shop/pkg/orderHistory/model"
+	_orderHistoryRepository "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
+)
+
+func bangkokTime(t *testing.T, value string) time.Time {
+	loc, err := time.LoadLocation("Asia/Bangkok")
+	assert.NoError(t, err)
+	at, err := time.ParseInLocation("2006-01-02 15:04", value, loc)
+	assert.NoError(t, err)
+	return at
+}
+
+func happyHourPromotions(t *testing.T) []_foodShopModel.Promotion {
+	promotions, err := _foodShopRepository.ParsePromotions([]byte(`[
+		{
+			"code":"HAPPYPINK","type":"PAIR","title":"Afternoon PINK pairs 15% off","priority":10,
+			"eligibleCodes":["PINK"],"params":{"discountPercent":15,"bundleSize":2},
+			"schedule":{"startDate":"2026-10-01","endDate":"2026-10-31","days":["MON","TUE","WED","THU","FRI"],"timeFrom":"14:00","timeTo":"17:00","timezone":"Asia/Bangkok"}
+		}
+	]`))
+	assert.NoError(t, err)
+	return promotions
+}
+
+func TestQuoteOrder_TimeWindowedPromotion(t *testing.T) {
+	cases := []struct {
+		label                string
+		at                   string
+		expectedPairDiscount domain.Money
+	}{
+		{label: "Wednesday 15:00 => inside window", at: "2026-10-14 15:00", expectedPairDiscount: domain.THB(24)},
+		{label: "Wednesday 14:00 => window start is inclusive", at: "2026-10-14 14:00", expectedPairDiscount: domain.THB(24)},
+		{label: "Wednesday 17:00 => window end is exclusive", at: "2026-10-14 17:00", expectedPairDiscount: domain.THB(0)},
+		{label: "Saturday 15:00 => weekday-only", at: "2026-10-17 15:00", expectedPairDiscount: domain.THB(0)},
+		{label: "November weekday 15:00 => after end date", at: "2026-11-04 15:00", expectedPairDiscount: domain.THB(0)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			now := bangkokTime(t, c.at)
+
+			foodShopRepositoryMock := new(_foodShopRepository.FoodShopRepositoryMock)
+			orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+
+			foodShopRepositoryMock.Test(t)
+			orderHistoryRepositoryMock.Test(t)
+
+			foodShopRepositoryMock.
+				On("FindMenuItemByCode", _foodShopModel.MenuItemCode("PINK")).
+				Return(_foodShopModel.MenuItem{Code: "PINK", Name: "Pink set", Price: domain.THB(80)}, nil).
+				Once()
+			foodShopRepositoryMock.On("ListPromotions").Return(happyHourPromotions(t), nil).Once()
+			orderHistoryRepositoryMock.
+				On("Add", mock.MatchedBy(func(entry _orderHistoryModel.OrderHistoryEntry) bool {
+					return entry.CreatedAt.Equal(now)
+				})).
+				Return(nil).
+				Once()
+
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				foodShopRepositoryMock,
+				orderHistoryRepositoryMock,
+				_foodShopService.WithClock(domain.FixedClock(now)),
+			)
+
+			res, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{
+				Items: map[string]int{"PINK": 2},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, c.expectedPairDiscount, res.PairDiscount)
+			assert.Equal(t, domain.THB(160).Sub(c.expectedPairDiscount), res.Total)
+
+			foodShopRepositoryMock.AssertExpectations(t)
+			orderHistoryRepositoryMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetPromotions_FlagsInactive(t *testing.T) {
+	foodShopRepositoryMock := new(_foodShopRepository.FoodShopRepositoryMock)
+	foodShopRepositoryMock.Test(t)
+
+	promotions := append(_foodShopRepository.DefaultPromotions(), happyHourPromotions(t)...)
+	foodShopRepositoryMock.On("ListPromotions").Return(promotions, nil).Once()
+
+	foodShopService := _foodShopService.NewFoodShopServiceImpl(
+		foodShopRepositoryMock,
+		new(_orderHistoryRepository.OrderHistoryRepositoryMock),
+		_foodShopService.WithClock(domain.FixedClock(bangkokTime(t, "2026-10-14 09:00"))),
+	)
+
+	statuses, err := foodShopService.GetPromotions()
+	assert.NoError(t, err)
+
+	active := make(map[string]bool, len(statuses))
+	for _, status := range statuses {
+		active[status.Code] = status.Active
+	}
+	assert.Equal(t, map[string]bool{"MEMBER": true, "PAIR": true, "HAPPYPINK": false}, active)
+
+	foodShopRepositoryMock.AssertExpectations(t)
+}
+
+func TestParsePromotions_InvalidSchedule(t *testing.T) {
+	cases := []string{
+		`[{"code":"X","type":"MEMBER","schedule":{"timezone":"Mars/Olympus"}}]`,
+		`[{"code":"X","type":"MEMBER","schedule":{"startDate":"14/10/2026"}}]`,
+		`[{"code":"X","type":"MEMBER","schedule":{"startDate":"2026-10-31","endDate":"2026-10-01"}}]`,
+		`[{"code":"X","type":"MEMBER","schedule":{"days":["FUNDAY"]}}]`,
+		`[{"code":"X","type":"MEMBER","schedule":{"timeFrom":"14:00"}}]`,
+		`[{"code":"X","type":"MEMBER","schedule":{"timeFrom":"25:00","timeTo":"26:00"}}]`,
+		`[{"code":"X","type":"MEMBER","schedule":{"timeFrom":"11:00","timeTo":"11:00"}}]`,
+	}
+
+	for _, data := range cases {
+		_, err := _foodShopRepository.ParsePromotions([]byte(data))
+		assert.Error(t, err, data)
+	}
+}
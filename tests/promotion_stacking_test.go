@@ -0,0 +1,166 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopException "github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopPromotion "github.com/TewApirat/food-shop/pkg/foodShop/promotion"
+	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
+	_orderHistoryRepository "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
+)
+
+// PAIR5 and PINK15 are alternatives; the member discount is capped at 20 THB
+// and does not stack with PINK15.
+const stackingPromotions = `[
+	{"code":"PAIR5","type":"PAIR","title":"Pair 5%","priority":10,"eligibleCodes":["ORANGE","PINK","GREEN"],
+	 "params":{"discountPercent":5,"bundleSize":2},"stacking":{"exclusiveGroup":"pair"}},
+	{"code":"PINK15","type":"PAIR","title":"Pink pair 15%","priority":10,"eligibleCodes":["PINK"],
+	 "params":{"discountPercent":15,"bundleSize":2},"stacking":{"exclusiveGroup":"PAIR"}},
+	{"code":"MEMBER","type":"MEMBER","title":"Member tiers","priority":20,
+	 "stacking":{"cannotCombineWith":["pink15"],"maxDiscount":20}}
+]`
+
+func TestQuoteOrder_PromotionStacking(t *testing.T) {
+	type tc struct {
+		label string
+		in    _foodShopModel.PurchasingRequest
+
+		expectedPairDiscount   domain.Money
+		expectedMemberDiscount domain.Money
+		expectedTotal          domain.Money
+		expectedChosen         []string
+		expectedRejected       []_foodShopModel.PromotionCombination
+	}
+
+	cases := []tc{
+		{
+			label:                  "Walk-in: PINK(2)+GREEN(2) 240, PINK15 takes 24 beats PAIR5 12",
+			in:                     _foodShopModel.PurchasingRequest{Items: map[string]int{"PINK": 2, "GREEN": 2}},
+			expectedPairDiscount:   domain.THB(24),
+			expectedMemberDiscount: domain.THB(0),
+			expectedTotal:          domain.THB(216),
+			expectedChosen:         []string{"PINK15"},
+			expectedRejected: []_foodShopModel.PromotionCombination{
+				{Codes: []string{"PAIR5", "MEMBER"}, Total: domain.THB(228)},
+			},
+		},
+		{
+			label:                  "Gold member: PAIR5 12 + member 22.80 capped at 20 => 208 beats PINK15 alone 216",
+			in:                     _foodShopModel.PurchasingRequest{Items: map[string]int{"PINK": 2, "GREEN": 2}, MemberID: goldMemberID},
+			expectedPairDiscount:   domain.THB(12),
+			expectedMemberDiscount: domain.THB(20),
+			expectedTotal:          domain.THB(208),
+			expectedChosen:         []string{"PAIR5", "MEMBER"},
+			expectedRejected: []_foodShopModel.PromotionCombination{
+				{Codes: []string{"PINK15"}, Total: domain.THB(216)},
+			},
+		},
+		{
+			label:                  "Walk-in: GREEN(10)+PINK(2) 560, PAIR5 takes 28 beats PINK15 24",
+			in:                     _foodShopModel.PurchasingRequest{Items: map[string]int{"GREEN": 10, "PINK": 2}},
+			expectedPairDiscount:   domain.THB(28),
+			expectedMemberDiscount: domain.THB(0),
+			expectedTotal:          domain.THB(532),
+			expectedChosen:         []string{"PAIR5", "MEMBER"},
+			expectedRejected: []_foodShopModel.PromotionCombination{
+				{Codes: []string{"PINK15"}, Total: domain.THB(536)},
+			},
+		},
+		{
+			label:                  "Tie: RED(1), nothing applies, the first combination wins",
+			in:                     _foodShopModel.PurchasingRequest{Items: map[string]int{"RED": 1}},
+			expectedPairDiscount:   domain.THB(0),
+			expectedMemberDiscount: domain.THB(0),
+			expectedTotal:          domain.THB(50),
+			expectedChosen:         []string{"PAIR5", "MEMBER"},
+			expectedRejected: []_foodShopModel.PromotionCombination{
+				{Codes: []string{"PINK15"}, Total: domain.THB(50)},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			promotions, err := _foodShopRepository.ParsePromotions([]byte(stackingPromotions))
+			assert.NoError(t, err)
+
+			foodShopRepositoryMock := new(_foodShopRepository.FoodShopRepositoryMock)
+			orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+
+			foodShopRepositoryMock.Test(t)
+			orderHistoryRepositoryMock.Test(t)
+
+			for code, item := range _foodShopRepository.DefaultMenu() {
+				foodShopRepositoryMock.On("FindMenuItemByCode", code).Return(item, nil).Maybe()
+			}
+			foodShopRepositoryMock.On("ListPromotions").Return(promotions, nil).Once()
+			orderHistoryRepositoryMock.On("Add", mock.Anything).Return(nil).Once()
+
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				foodShopRepositoryMock,
+				orderHistoryRepositoryMock,
+				_foodShopService.WithMemberRepository(testMemberRepository()),
+			)
+
+			res, err := foodShopService.QuoteOrder(c.in)
+			assert.NoError(t, err)
+
+			assert.Equal(t, c.expectedPairDiscount, res.PairDiscount)
+			assert.Equal(t, c.expectedMemberDiscount, res.MemberDiscount)
+			assert.Equal(t, c.expectedTotal, res.Total)
+			assert.Equal(t, c.expectedChosen, res.ChosenCombination)
+			assert.Equal(t, c.expectedRejected, res.RejectedCombinations)
+
+			foodShopRepositoryMock.AssertExpectations(t)
+			orderHistoryRepositoryMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCombinations_MaximalCompatibleSets(t *testing.T) {
+	promotions := []_foodShopModel.Promotion{
+		{Code: "A", Stacking: _foodShopModel.PromotionStacking{ExclusiveGroup: "G"}},
+		{Code: "B", Stacking: _foodShopModel.PromotionStacking{ExclusiveGroup: "G"}},
+		{Code: "C", Stacking: _foodShopModel.PromotionStacking{CannotCombineWith: []string{"A"}}},
+		{Code: "D"},
+	}
+
+	combinations, err := _foodShopPromotion.Combinations(promotions)
+	assert.NoError(t, err)
+
+	var got [][]string
+	for _, combination := range combinations {
+		var codes []string
+		for _, promo := range combination {
+			codes = append(codes, promo.Code)
+		}
+		got = append(got, codes)
+	}
+	assert.Equal(t, [][]string{{"A", "D"}, {"B", "C", "D"}}, got)
+}
+
+func TestParsePromotions_InvalidStacking(t *testing.T) {
+	cases := []struct {
+		label string
+		data  string
+	}{
+		{label: "Fail: unknown cannotCombineWith", data: `[{"code":"MEMBER","type":"MEMBER","stacking":{"cannotCombineWith":["NOPE"]}}]`},
+		{label: "Fail: cannot combine with itself", data: `[{"code":"MEMBER","type":"MEMBER","stacking":{"cannotCombineWith":["member"]}}]`},
+		{label: "Fail: negative cap", data: `[{"code":"MEMBER","type":"MEMBER","stacking":{"maxDiscount":-1}}]`},
+		{label: "Fail: unknown stacking field", data: `[{"code":"MEMBER","type":"MEMBER","stacking":{"group":"X"}}]`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			_, err := _foodShopRepository.ParsePromotions([]byte(c.data))
+			var target *_foodShopException.InvalidPromotionConfigError
+			assert.ErrorAs(t, err, &target)
+		})
+	}
+}
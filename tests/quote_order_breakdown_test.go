@@ -0,0 +1,153 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
+	_orderHistoryModel "github.com/TewApirat/food-shop/pkg/orderHistory/model"
+	_orderHistoryRepository "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
+)
+
+func TestQuoteOrder_AppliedPromotionsBreakdown(t *testing.T) {
+	type tc struct {
+		label      string
+		promotions string
+		in         _foodShopModel.PurchasingRequest
+		expected   []_foodShopModel.AppliedPromotion
+	}
+
+	cases := []tc{
+		{
+			label: "Default promotions: GREEN(2)+RED(1), gold member => pair then member",
+			in:    _foodShopModel.PurchasingRequest{Items: map[string]int{"GREEN": 2, "RED": 1}, MemberID: goldMemberID},
+			expected: []_foodShopModel.AppliedPromotion{
+				{
+					Code:        "PAIR",
+					Items:       map[_foodShopModel.MenuItemCode]int{"GREEN": 2},
+					Base:        domain.THB(80),
+					Rate:        domain.PercentRate(5),
+					Discount:    domain.THB(4),
+					Explanation: "5% off bundles of 2: 2 x GREEN (80.00 THB)",
+				},
+				{
+					Code:        "MEMBER",
+					Base:        domain.THB(126),
+					Rate:        domain.PercentRate(10),
+					Discount:    satang(1260),
+					Explanation: "member 10% off the remaining 126.00 THB",
+				},
+			},
+		},
+		{
+			label: "No discount: RED(1), walk-in => nothing listed",
+			in:    _foodShopModel.PurchasingRequest{Items: map[string]int{"RED": 1}},
+		},
+		{
+			label: "Free item: BLUE(4), buy 3 get 1 free",
+			promotions: `[{"code":"BLUE4","type":"BUY_X_GET_Y","title":"Blue 3+1","priority":10,"eligibleCodes":["BLUE"],
+				"params":{"buyQty":3,"freeQty":1}}]`,
+			in: _foodShopModel.PurchasingRequest{Items: map[string]int{"BLUE": 4}},
+			expected: []_foodShopModel.AppliedPromotion{
+				{
+					Code:        "BLUE4",
+					Items:       map[_foodShopModel.MenuItemCode]int{"BLUE": 4},
+					FreeUnits:   map[_foodShopModel.MenuItemCode]int{"BLUE": 1},
+					Base:        domain.THB(30),
+					Discount:    domain.THB(30),
+					Explanation: "buy 3 get 1 free: 1 x BLUE free (30.00 THB)",
+				},
+			},
+		},
+		{
+			label: "Combo: RED(2)+BLUE(2) as two 70 THB combos",
+			promotions: `[{"code":"RB","type":"COMBO","title":"Red + Blue 70","priority":10,
+				"params":{"comboItems":{"RED":1,"BLUE":1},"comboPrice":70}}]`,
+			in: _foodShopModel.PurchasingRequest{Items: map[string]int{"RED": 2, "BLUE": 2}},
+			expected: []_foodShopModel.AppliedPromotion{
+				{
+					Code:        "RB",
+					Items:       map[_foodShopModel.MenuItemCode]int{"RED": 2, "BLUE": 2},
+					Base:        domain.THB(160),
+					Discount:    domain.THB(20),
+					Explanation: "2 x combo at 70.00 THB instead of 80.00 THB: 2 x BLUE, 2 x RED",
+				},
+			},
+		},
+		{
+			label: "Spend tier: RED(11) 550 reaches the 500 tier",
+			promotions: `[{"code":"SPEND","type":"SPEND_TIER","title":"Spend 500","priority":10,
+				"params":{"tiers":[{"minSpend":500,"amountOff":30}]}}]`,
+			in: _foodShopModel.PurchasingRequest{Items: map[string]int{"RED": 11}},
+			expected: []_foodShopModel.AppliedPromotion{
+				{
+					Code:        "SPEND",
+					Base:        domain.THB(550),
+					Discount:    domain.THB(30),
+					Explanation: "spent 550.00 THB, reached the 500.00 THB tier: 30.00 THB off",
+				},
+			},
+		},
+		{
+			label: "Capped: platinum member 15% of 200 capped at 20",
+			promotions: `[{"code":"MEMBER","type":"MEMBER","title":"Member tiers","priority":10,
+				"stacking":{"maxDiscount":20}}]`,
+			in: _foodShopModel.PurchasingRequest{Items: map[string]int{"RED": 4}, MemberID: "P001"},
+			expected: []_foodShopModel.AppliedPromotion{
+				{
+					Code:        "MEMBER",
+					Base:        domain.THB(200),
+					Rate:        domain.PercentRate(15),
+					Discount:    domain.THB(20),
+					Explanation: "member 15% off the remaining 200.00 THB, capped at 20.00 THB",
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			promotions := _foodShopRepository.DefaultPromotions()
+			if c.promotions != "" {
+				var err error
+				promotions, err = _foodShopRepository.ParsePromotions([]byte(c.promotions))
+				assert.NoError(t, err)
+			}
+
+			foodShopRepositoryMock := new(_foodShopRepository.FoodShopRepositoryMock)
+			orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+
+			foodShopRepositoryMock.Test(t)
+			orderHistoryRepositoryMock.Test(t)
+
+			for code, item := range _foodShopRepository.DefaultMenu() {
+				foodShopRepositoryMock.On("FindMenuItemByCode", code).Return(item, nil).Maybe()
+			}
+			foodShopRepositoryMock.On("ListPromotions").Return(promotions, nil).Once()
+			orderHistoryRepositoryMock.
+				On("Add", mock.MatchedBy(func(entry _orderHistoryModel.OrderHistoryEntry) bool {
+					return assert.ObjectsAreEqual(c.expected, entry.AppliedPromotions)
+				})).
+				Return(nil).
+				Once()
+
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				foodShopRepositoryMock,
+				orderHistoryRepositoryMock,
+				_foodShopService.WithMemberRepository(testMemberRepository()),
+			)
+
+			res, err := foodShopService.QuoteOrder(c.in)
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, res.AppliedPromotions)
+
+			foodShopRepositoryMock.AssertExpectations(t)
+			orderHistoryRepositoryMock.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
+	_orderHistoryRepository "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
+)
+
+// Menu prices: RED 50, GREEN 40, BLUE 30, YELLOW 50, PINK 80, PURPLE 90.
+const comboPromotions = `[
+	{"code":"REDBLUE","type":"COMBO","title":"Any RED + BLUE for 70 THB","priority":1,"params":{"comboItems":{"RED":1,"BLUE":1},"comboPrice":70}},
+	{"code":"FAMILY","type":"COMBO","title":"PINK + PURPLE + YELLOW family set 200 THB","priority":1,"params":{"comboItems":{"PINK":1,"PURPLE":1,"YELLOW":1},"comboPrice":200}},
+	{"code":"TRIO","type":"COMBO","title":"RED + BLUE + YELLOW for 105 THB","priority":1,"params":{"comboItems":{"RED":1,"BLUE":1,"YELLOW":1},"comboPrice":105}},
+	{"code":"REDGREEN","type":"COMBO","title":"RED + GREEN for 75 THB","priority":1,"params":{"comboItems":{"RED":1,"GREEN":1},"comboPrice":75}},
+	{"code":"BLUEYELLOW","type":"COMBO","title":"BLUE + YELLOW for 65 THB","priority":1,"params":{"comboItems":{"BLUE":1,"YELLOW":1},"comboPrice":65}},
+	{"code":"GREENYELLOW","type":"COMBO","title":"GREEN + YELLOW for 79.50 THB","priority":1,"params":{"comboItems":{"GREEN":1,"YELLOW":1},"comboPrice":"79.50"}},
+	{"code":"PAIR","type":"PAIR","title":"Pair discount 5%","priority":10,"eligibleCodes":["ORANGE","PINK","GREEN"],"params":{"discountPercent":5,"bundleSize":2}}
+]`
+
+func TestQuoteOrder_ComboPromotions(t *testing.T) {
+	type tc struct {
+		label string
+		items map[string]int
+
+		expectedPromotionDiscount domain.Money
+		expectedPairDiscount      domain.Money
+	}
+
+	cases := []tc{
+		{
+			label:                     "Single combo: RED+BLUE => 80 for 70",
+			items:                     map[string]int{"RED": 1, "BLUE": 1},
+			expectedPromotionDiscount: domain.THB(10),
+		},
+		{
+			label:                     "Repeated combo: RED(2)+BLUE(3) => 2 combos, 1 BLUE left",
+			items:                     map[string]int{"RED": 2, "BLUE": 3},
+			expectedPromotionDiscount: domain.THB(20),
+		},
+		{
+			label:                     "Family set: PINK+PURPLE+YELLOW => 220 for 200",
+			items:                     map[string]int{"PINK": 1, "PURPLE": 1, "YELLOW": 1},
+			expectedPromotionDiscount: domain.THB(20),
+		},
+		{
+			label:                     "Overlap: RED+BLUE+YELLOW => TRIO (25) beats REDBLUE (10) and BLUEYELLOW (15)",
+			items:                     map[string]int{"RED": 1, "BLUE": 1, "YELLOW": 1},
+			expectedPromotionDiscount: domain.THB(25),
+		},
+		{
+			label:                     "Overlap: RED+BLUE+YELLOW+GREEN => REDGREEN+BLUEYELLOW (30) beats greedy TRIO (25)",
+			items:                     map[string]int{"RED": 1, "BLUE": 1, "YELLOW": 1, "GREEN": 1},
+			expectedPromotionDiscount: domain.THB(30),
+		},
+		{
+			label:                     "Overlap: family set takes YELLOW, REDBLUE takes the rest => 20 + 10 beats TRIO (25)",
+			items:                     map[string]int{"RED": 1, "BLUE": 1, "YELLOW": 1, "PINK": 1, "PURPLE": 1},
+			expectedPromotionDiscount: domain.THB(30),
+		},
+		{
+			label:                     "Combo claims units: PINK(2)+PURPLE+YELLOW => family set, no PINK pair left",
+			items:                     map[string]int{"PINK": 2, "PURPLE": 1, "YELLOW": 1},
+			expectedPromotionDiscount: domain.THB(20),
+			expectedPairDiscount:      domain.THB(0),
+		},
+		{
+			label:                     "Combo claims units: PINK(3)+PURPLE+YELLOW => family set + 1 PINK pair",
+			items:                     map[string]int{"PINK": 3, "PURPLE": 1, "YELLOW": 1},
+			expectedPromotionDiscount: domain.THB(20),
+			expectedPairDiscount:      domain.THB(8),
+		},
+		{
+			label:                     "Satang combo price: GREEN+YELLOW => 90 for 79.50",
+			items:                     map[string]int{"GREEN": 1, "YELLOW": 1},
+			expectedPromotionDiscount: satang(1050),
+		},
+		{
+			label: "No combo matches: GREEN+ORANGE => 0",
+			items: map[string]int{"GREEN": 1, "ORANGE": 1},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			promotions, err := _foodShopRepository.ParsePromotions([]byte(comboPromotions))
+			assert.NoError(t, err)
+
+			foodShopRepositoryMock := new(_foodShopRepository.FoodShopRepositoryMock)
+			orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+
+			foodShopRepositoryMock.Test(t)
+			orderHistoryRepositoryMock.Test(t)
+
+			for code, item := range _foodShopRepository.DefaultMenu() {
+				foodShopRepositoryMock.On("FindMenuItemByCode", code).Return(item, nil).Maybe()
+			}
+			foodShopRepositoryMock.On("ListPromotions").Return(promotions, nil).Once()
+			orderHistoryRepositoryMock.On("Add", mock.Anything).Return(nil).Once()
+
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				foodShopRepositoryMock,
+				orderHistoryRepositoryMock,
+			)
+
+			res, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{Items: c.items})
+			assert.NoError(t, err)
+
+			assert.Equal(t, c.expectedPromotionDiscount, res.PromotionDiscount)
+			assert.Equal(t, c.expectedPairDiscount, res.PairDiscount)
+			assert.Equal(t, res.Subtotal.Sub(c.expectedPromotionDiscount).Sub(c.expectedPairDiscount), res.Total)
+
+			orderHistoryRepositoryMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestParsePromotions_InvalidCombo(t *testing.T) {
+	cases := []string{
+		`[{"code":"X","type":"COMBO","params":{"comboItems":{"RED":1},"comboPrice":40}}]`,
+		`[{"code":"X","type":"COMBO","params":{"comboItems":{"RED":0,"BLUE":2},"comboPrice":40}}]`,
+		`[{"code":"X","type":"COMBO","params":{"comboItems":{"RED":1,"BLUE":1},"comboPrice":-1}}]`,
+	}
+
+	for _, data := range cases {
+		_, err := _foodShopRepository.ParsePromotions([]byte(data))
+		assert.Error(t, err, data)
+	}
+}
@@ -0,0 +1,324 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	_couponModel "github.com/TewApirat/food-shop/pkg/coupon/model"
+	_couponRepository "github.com/TewApirat/food-shop/pkg/coupon/repository"
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopException "github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
+	_orderHistoryModel "github.com/TewApirat/food-shop/pkg/orderHistory/model"
+	_orderHistoryRepository "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
+)
+
+var couponTestNow = time.Date(2026, time.October, 14, 12, 0, 0, 0, time.UTC)
+
+func testCoupons() []_couponModel.Coupon {
+	return []_couponModel.Coupon{
+		{Code: "FIFTY", Type: _couponModel.CouponTypeFixed, Amount: domain.THB(50), MinSpend: domain.THB(300)},
+		{Code: "ORANGE10", Type: _couponModel.CouponTypePercent, Rate: domain.PercentRate(10), EligibleCodes: []_foodShopModel.MenuItemCode{"ORANGE"}},
+		{Code: "BIGFIXED", Type: _couponModel.CouponTypeFixed, Amount: domain.THB(500)},
+		{Code: "OLD", Type: _couponModel.CouponTypeFixed, Amount: domain.THB(10), ExpiresAt: couponTestNow.Add(-time.Hour)},
+		{Code: "ONCE", Type: _couponModel.CouponTypeFixed, Amount: domain.THB(5), MaxRedemptions: 1},
+		{Code: "ONEPERCUST", Type: _couponModel.CouponTypeFixed, Amount: domain.THB(5), MaxRedemptionsPerCustomer: 1},
+	}
+}
+
+func newCouponTestService(t *testing.T, couponRepository _couponRepository.CouponRepository) (
+	_foodShopService.FoodShopService,
+	*_orderHistoryRepository.OrderHistoryRepositoryMock,
+) {
+	foodShopRepositoryMock := new(_foodShopRepository.FoodShopRepositoryMock)
+	orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+
+	foodShopRepositoryMock.Test(t)
+	orderHistoryRepositoryMock.Test(t)
+
+	menu := _foodShopRepository.DefaultMenu()
+	for code, item := range menu {
+		foodShopRepositoryMock.On("FindMenuItemByCode", code).Return(item, nil).Maybe()
+	}
+	foodShopRepositoryMock.On("ListPromotions").Return(_foodShopRepository.DefaultPromotions(), nil).Maybe()
+
+	foodShopService := _foodShopService.NewFoodShopServiceImpl(
+		foodShopRepositoryMock,
+		orderHistoryRepositoryMock,
+		_foodShopService.WithCouponRepository(couponRepository),
+		_foodShopService.WithClock(domain.FixedClock(couponTestNow)),
+		_foodShopService.WithMemberRepository(testMemberRepository()),
+	)
+	return foodShopService, orderHistoryRepositoryMock
+}
+
+func TestQuoteOrder_CouponSuccess(t *testing.T) {
+	type tc struct {
+		label string
+		in    _foodShopModel.PurchasingRequest
+
+		expectedCouponDiscount domain.Money
+		expectedTotal          domain.Money
+	}
+
+	cases := []tc{
+		{
+			label: "Fixed: ORANGE(3) 360 - pair 12 = 348, FIFTY => 298",
+			in: _foodShopModel.PurchasingRequest{
+				Items:   map[string]int{"ORANGE": 3},
+				Coupons: []string{"fifty"},
+			},
+			expectedCouponDiscount: domain.THB(50),
+			expectedTotal:          domain.THB(298),
+		},
+		{
+			label: "Percent on eligible items only: RED(1)+ORANGE(1), ORANGE10 => 10% of 120",
+			in: _foodShopModel.PurchasingRequest{
+				Items:   map[string]int{"RED": 1, "ORANGE": 1},
+				Coupons: []string{"ORANGE10"},
+			},
+			expectedCouponDiscount: domain.THB(12),
+			expectedTotal:          domain.THB(158),
+		},
+		{
+			label: "Fixed capped at remaining bill: BLUE(1), BIGFIXED => 30",
+			in: _foodShopModel.PurchasingRequest{
+				Items:   map[string]int{"BLUE": 1},
+				Coupons: []string{"BIGFIXED"},
+			},
+			expectedCouponDiscount: domain.THB(30),
+			expectedTotal:          domain.THB(0),
+		},
+		{
+			label: "Stacks after member: RED(4) 200 - member 20 = 180, duplicate ONCE applied once => 175",
+			in: _foodShopModel.PurchasingRequest{
+				Items:    map[string]int{"RED": 4},
+				MemberID: goldMemberID,
+				Coupons:  []string{"ONCE", " once "},
+			},
+			expectedCouponDiscount: domain.THB(5),
+			expectedTotal:          domain.THB(175),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			foodShopService, orderHistoryRepositoryMock := newCouponTestService(t,
+				_couponRepository.NewCouponRepositoryImpl(testCoupons()))
+
+			orderHistoryRepositoryMock.
+				On("Add", mock.MatchedBy(func(entry _orderHistoryModel.OrderHistoryEntry) bool {
+					return entry.CouponDiscount == c.expectedCouponDiscount &&
+						entry.Total == c.expectedTotal &&
+						len(entry.Coupons) == 1
+				})).
+				Return(nil).
+				Once()
+
+			res, err := foodShopService.QuoteOrder(c.in)
+			assert.NoError(t, err)
+			assert.Equal(t, c.expectedCouponDiscount, res.CouponDiscount)
+			assert.Equal(t, c.expectedTotal, res.Total)
+			assert.Len(t, res.Coupons, 1)
+
+			orderHistoryRepositoryMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestQuoteOrder_CouponFail(t *testing.T) {
+	type tc struct {
+		label string
+		in    _foodShopModel.PurchasingRequest
+		check func(t *testing.T, err error)
+	}
+
+	cases := []tc{
+		{
+			label: "Fail: unknown coupon",
+			in:    _foodShopModel.PurchasingRequest{Items: map[string]int{"RED": 1}, Coupons: []string{"NOPE"}},
+			check: func(t *testing.T, err error) {
+				var target *_foodShopException.InvalidCouponError
+				assert.ErrorAs(t, err, &target)
+				assert.Equal(t, "NOPE", target.Code)
+			},
+		},
+		{
+			label: "Fail: blank coupon",
+			in:    _foodShopModel.PurchasingRequest{Items: map[string]int{"RED": 1}, Coupons: []string{"  "}},
+			check: func(t *testing.T, err error) {
+				var target *_foodShopException.InvalidCouponError
+				assert.ErrorAs(t, err, &target)
+			},
+		},
+		{
+			label: "Fail: expired coupon",
+			in:    _foodShopModel.PurchasingRequest{Items: map[string]int{"RED": 1}, Coupons: []string{"OLD"}},
+			check: func(t *testing.T, err error) {
+				var target *_foodShopException.ExpiredCouponError
+				assert.ErrorAs(t, err, &target)
+			},
+		},
+		{
+			label: "Fail: minimum spend not met",
+			in:    _foodShopModel.PurchasingRequest{Items: map[string]int{"RED": 1}, Coupons: []string{"FIFTY"}},
+			check: func(t *testing.T, err error) {
+				var target *_foodShopException.CouponNotApplicableError
+				assert.ErrorAs(t, err, &target)
+			},
+		},
+		{
+			label: "Fail: no eligible items",
+			in:    _foodShopModel.PurchasingRequest{Items: map[string]int{"RED": 1}, Coupons: []string{"ORANGE10"}},
+			check: func(t *testing.T, err error) {
+				var target *_foodShopException.CouponNotApplicableError
+				assert.ErrorAs(t, err, &target)
+			},
+		},
+		{
+			label: "Fail: per-customer coupon without customer id",
+			in:    _foodShopModel.PurchasingRequest{Items: map[string]int{"RED": 1}, Coupons: []string{"ONEPERCUST"}},
+			check: func(t *testing.T, err error) {
+				var target *_foodShopException.CouponNotApplicableError
+				assert.ErrorAs(t, err, &target)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			foodShopService, orderHistoryRepositoryMock := newCouponTestService(t,
+				_couponRepository.NewCouponRepositoryImpl(testCoupons()))
+
+			res, err := foodShopService.QuoteOrder(c.in)
+			assert.Equal(t, _foodShopModel.OrderQuote{}, res)
+			c.check(t, err)
+
+			orderHistoryRepositoryMock.AssertNotCalled(t, "Add", mock.Anything)
+		})
+	}
+}
+
+func TestQuoteOrder_CouponOnFreeItems(t *testing.T) {
+	type tc struct {
+		label            string
+		items            map[string]int
+		expectedTotal    domain.Money
+		expectedDiscount domain.Money
+		expectedReason   string
+	}
+
+	// ORANGE2RED adds a free RED for every 2 ORANGE; RED50 takes 50% off RED.
+	cases := []tc{
+		{
+			label:            "Only the paid RED is discounted: 240 + 100 - 50 free - 25",
+			items:            map[string]int{"ORANGE": 2, "RED": 2},
+			expectedTotal:    domain.THB(265),
+			expectedDiscount: domain.THB(25),
+		},
+		{
+			label:          "Fail: the only RED is already free",
+			items:          map[string]int{"ORANGE": 2},
+			expectedReason: "eligible items have nothing left to pay",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			promotions, err := _foodShopRepository.ParsePromotions([]byte(autoAddPromotions))
+			assert.NoError(t, err)
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				_foodShopRepository.NewFoodShopRepositoryImpl(_foodShopRepository.DefaultMenu(), promotions),
+				_orderHistoryRepository.NewOrderHistoryRepositoryImpl(),
+				_foodShopService.WithClock(domain.FixedClock(couponTestNow)),
+				_foodShopService.WithCouponRepository(_couponRepository.NewCouponRepositoryImpl([]_couponModel.Coupon{
+					{Code: "RED50", Type: _couponModel.CouponTypePercent, Rate: domain.PercentRate(50), EligibleCodes: []_foodShopModel.MenuItemCode{"RED"}},
+				})),
+			)
+
+			res, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{Items: c.items, Coupons: []string{"RED50"}})
+			if c.expectedReason != "" {
+				var target *_foodShopException.CouponNotApplicableError
+				if assert.ErrorAs(t, err, &target) {
+					assert.Equal(t, c.expectedReason, target.Reason)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, c.expectedTotal, res.Total)
+			assert.Equal(t, c.expectedDiscount, res.CouponDiscount)
+			for _, line := range res.Lines {
+				if line.Code == "ORANGE" {
+					assert.Empty(t, line.Discounts, "the coupon stays off ORANGE")
+				}
+			}
+		})
+	}
+}
+
+func TestQuoteOrder_CouponRedemptionLimits(t *testing.T) {
+	t.Run("Per-code limit: second order fails", func(t *testing.T) {
+		foodShopService, orderHistoryRepositoryMock := newCouponTestService(t,
+			_couponRepository.NewCouponRepositoryImpl(testCoupons()))
+		orderHistoryRepositoryMock.On("Add", mock.Anything).Return(nil).Once()
+
+		req := _foodShopModel.PurchasingRequest{Items: map[string]int{"RED": 1}, Coupons: []string{"ONCE"}}
+
+		_, err := foodShopService.QuoteOrder(req)
+		assert.NoError(t, err)
+
+		_, err = foodShopService.QuoteOrder(req)
+		var target *_foodShopException.CouponExhaustedError
+		assert.ErrorAs(t, err, &target)
+		assert.False(t, target.PerCustomer)
+
+		orderHistoryRepositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("Per-customer limit: another customer can still redeem", func(t *testing.T) {
+		foodShopService, orderHistoryRepositoryMock := newCouponTestService(t,
+			_couponRepository.NewCouponRepositoryImpl(testCoupons()))
+		orderHistoryRepositoryMock.On("Add", mock.Anything).Return(nil).Twice()
+
+		req := _foodShopModel.PurchasingRequest{Items: map[string]int{"RED": 1}, CustomerID: "C001", Coupons: []string{"ONEPERCUST"}}
+
+		_, err := foodShopService.QuoteOrder(req)
+		assert.NoError(t, err)
+
+		_, err = foodShopService.QuoteOrder(req)
+		var target *_foodShopException.CouponExhaustedError
+		assert.ErrorAs(t, err, &target)
+		assert.True(t, target.PerCustomer)
+
+		req.CustomerID = "C002"
+		_, err = foodShopService.QuoteOrder(req)
+		assert.NoError(t, err)
+
+		orderHistoryRepositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("Redeem failure keeps the order out of history", func(t *testing.T) {
+		couponRepositoryMock := new(_couponRepository.CouponRepositoryMock)
+		couponRepositoryMock.Test(t)
+		couponRepositoryMock.On("FindByCode", "ONCE").Return(testCoupons()[4], nil).Once()
+		couponRepositoryMock.On("CountRedemptions", "ONCE", "").Return(0, 0, nil).Once()
+		couponRepositoryMock.On("Redeem", mock.Anything).
+			Return(&_foodShopException.CouponExhaustedError{Code: "ONCE", Limit: 1}).
+			Once()
+
+		foodShopService, orderHistoryRepositoryMock := newCouponTestService(t, couponRepositoryMock)
+
+		_, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{Items: map[string]int{"RED": 1}, Coupons: []string{"ONCE"}})
+		var target *_foodShopException.CouponExhaustedError
+		assert.ErrorAs(t, err, &target)
+
+		couponRepositoryMock.AssertExpectations(t)
+		orderHistoryRepositoryMock.AssertNotCalled(t, "Add", mock.Anything)
+	})
+}
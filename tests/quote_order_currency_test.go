@@ -0,0 +1,271 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	_exchangeRateModel "github.com/TewApirat/food-shop/pkg/exchangeRate/model"
+	_exchangeRateRepository "github.com/TewApirat/food-shop/pkg/exchangeRate/repository"
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopException "github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
+	_orderHistoryModel "github.com/TewApirat/food-shop/pkg/orderHistory/model"
+	_orderHistoryRepository "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
+)
+
+func TestQuoteOrder_DisplayCurrency(t *testing.T) {
+	type tc struct {
+		label    string
+		currency string
+		expected *_foodShopModel.DisplayTotal
+	}
+
+	updatedAt := _exchangeRateRepository.DefaultExchangeRates()[0].UpdatedAt
+
+	// RED(2) = 100 THB, no promotion applies.
+	cases := []tc{
+		{
+			label: "No display currency: THB only",
+		},
+		{
+			label:    "USD: 100 / 36.5 = 2.7397 => 2.74 USD",
+			currency: "USD",
+			expected: &_foodShopModel.DisplayTotal{
+				Total:         domain.Amount{Minor: 274, Currency: domain.CurrencyUSD},
+				Rate:          domain.ExchangeRate(36_500_000),
+				RateUpdatedAt: updatedAt,
+			},
+		},
+		{
+			label:    "cny is upper-cased: 100 / 5.05 = 19.802 => 19.80 CNY",
+			currency: " cny ",
+			expected: &_foodShopModel.DisplayTotal{
+				Total:         domain.Amount{Minor: 1980, Currency: domain.CurrencyCNY},
+				Rate:          domain.ExchangeRate(5_050_000),
+				RateUpdatedAt: updatedAt,
+			},
+		},
+		{
+			label:    "JPY has no minor unit: 100 / 0.2415 = 414.07 => 414 JPY",
+			currency: "JPY",
+			expected: &_foodShopModel.DisplayTotal{
+				Total:         domain.Amount{Minor: 414, Currency: domain.CurrencyJPY},
+				Rate:          domain.ExchangeRate(241_500),
+				RateUpdatedAt: updatedAt,
+			},
+		},
+		{
+			label:    "THB: shown as is at a rate of 1",
+			currency: "THB",
+			expected: &_foodShopModel.DisplayTotal{
+				Total: domain.Amount{Minor: 10000, Currency: domain.CurrencyTHB},
+				Rate:  domain.ExchangeRate(1_000_000),
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			foodShopRepositoryMock := new(_foodShopRepository.FoodShopRepositoryMock)
+			orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+
+			foodShopRepositoryMock.Test(t)
+			orderHistoryRepositoryMock.Test(t)
+
+			foodShopRepositoryMock.On("FindMenuItemByCode", _foodShopModel.MenuItemCode("RED")).
+				Return(_foodShopModel.MenuItem{Code: "RED", Name: "Red set", Price: domain.THB(50)}, nil).
+				Once()
+			expectDefaultPromotions(foodShopRepositoryMock)
+			orderHistoryRepositoryMock.
+				On("Add", mock.MatchedBy(func(entry _orderHistoryModel.OrderHistoryEntry) bool {
+					return entry.Total == domain.THB(100) && assert.ObjectsAreEqual(c.expected, entry.Display)
+				})).
+				Return(nil).
+				Once()
+
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				foodShopRepositoryMock,
+				orderHistoryRepositoryMock,
+				_foodShopService.WithExchangeRateRepository(
+					_exchangeRateRepository.NewExchangeRateRepositoryImpl(_exchangeRateRepository.DefaultExchangeRates())),
+			)
+
+			res, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{
+				Items:           map[string]int{"RED": 2},
+				DisplayCurrency: c.currency,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, domain.THB(100), res.Total)
+			assert.Equal(t, c.expected, res.Display)
+
+			foodShopRepositoryMock.AssertExpectations(t)
+			orderHistoryRepositoryMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestQuoteOrder_DisplayCurrencyFail(t *testing.T) {
+	type tc struct {
+		label        string
+		currency     string
+		withoutRepo  bool
+		expectsError func(t *testing.T, err error)
+	}
+
+	cases := []tc{
+		{
+			label:    "Fail: unsupported currency",
+			currency: "XYZ",
+			expectsError: func(t *testing.T, err error) {
+				var target *_foodShopException.UnsupportedCurrencyError
+				assert.ErrorAs(t, err, &target)
+				assert.Equal(t, "XYZ", target.Code)
+			},
+		},
+		{
+			label:    "Fail: no rate for EUR",
+			currency: "EUR",
+			expectsError: func(t *testing.T, err error) {
+				var target *_foodShopException.ExchangeRateNotFoundError
+				assert.ErrorAs(t, err, &target)
+				assert.Equal(t, domain.CurrencyEUR, target.Currency)
+			},
+		},
+		{
+			label:       "Fail: no exchange rate repository configured",
+			currency:    "USD",
+			withoutRepo: true,
+			expectsError: func(t *testing.T, err error) {
+				var target *_foodShopException.ExchangeRateNotFoundError
+				assert.ErrorAs(t, err, &target)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			foodShopRepositoryMock := new(_foodShopRepository.FoodShopRepositoryMock)
+			orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+			exchangeRateRepositoryMock := new(_exchangeRateRepository.ExchangeRateRepositoryMock)
+
+			foodShopRepositoryMock.Test(t)
+			orderHistoryRepositoryMock.Test(t)
+			exchangeRateRepositoryMock.Test(t)
+
+			foodShopRepositoryMock.On("FindMenuItemByCode", _foodShopModel.MenuItemCode("RED")).
+				Return(_foodShopModel.MenuItem{Code: "RED", Name: "Red set", Price: domain.THB(50)}, nil).
+				Once()
+			expectDefaultPromotions(foodShopRepositoryMock)
+			exchangeRateRepositoryMock.On("FindRate", domain.CurrencyEUR).
+				Return(_exchangeRateModel.ExchangeRate{}, &_foodShopException.ExchangeRateNotFoundError{Currency: domain.CurrencyEUR}).
+				Maybe()
+
+			var opts []_foodShopService.Option
+			if !c.withoutRepo {
+				opts = append(opts, _foodShopService.WithExchangeRateRepository(exchangeRateRepositoryMock))
+			}
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				foodShopRepositoryMock,
+				orderHistoryRepositoryMock,
+				opts...,
+			)
+
+			_, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{
+				Items:           map[string]int{"RED": 2},
+				DisplayCurrency: c.currency,
+			})
+			assert.Error(t, err)
+			c.expectsError(t, err)
+
+			foodShopRepositoryMock.AssertExpectations(t)
+			exchangeRateRepositoryMock.AssertExpectations(t)
+			orderHistoryRepositoryMock.AssertNotCalled(t, "Add", mock.Anything)
+		})
+	}
+}
+
+func TestLoadExchangeRatesFile_MatchesDefaults(t *testing.T) {
+	rates, err := _exchangeRateRepository.LoadExchangeRatesFile("../config/exchangeRates.json")
+	assert.NoError(t, err)
+
+	defaults := _exchangeRateRepository.DefaultExchangeRates()
+	assert.Len(t, rates, len(defaults))
+	for i := range defaults {
+		assert.Equal(t, defaults[i].Currency, rates[i].Currency)
+		assert.Equal(t, defaults[i].THBPerUnit, rates[i].THBPerUnit)
+		assert.True(t, defaults[i].UpdatedAt.Equal(rates[i].UpdatedAt))
+	}
+}
+
+func TestParseExchangeRates_Invalid(t *testing.T) {
+	cases := []struct {
+		label string
+		data  string
+	}{
+		{label: "Fail: not JSON", data: `{`},
+		{label: "Fail: unknown field", data: `[{"currency":"USD","rate":"36.5","updatedAt":"2026-10-01T09:00:00Z"}]`},
+		{label: "Fail: unsupported currency", data: `[{"currency":"XYZ","thbPerUnit":"1","updatedAt":"2026-10-01T09:00:00Z"}]`},
+		{label: "Fail: THB has no rate", data: `[{"currency":"THB","thbPerUnit":"1","updatedAt":"2026-10-01T09:00:00Z"}]`},
+		{label: "Fail: duplicate currency", data: `[{"currency":"USD","thbPerUnit":"36.5","updatedAt":"2026-10-01T09:00:00Z"},{"currency":"usd","thbPerUnit":"36","updatedAt":"2026-10-01T09:00:00Z"}]`},
+		{label: "Fail: missing rate", data: `[{"currency":"USD","updatedAt":"2026-10-01T09:00:00Z"}]`},
+		{label: "Fail: zero rate", data: `[{"currency":"USD","thbPerUnit":"0","updatedAt":"2026-10-01T09:00:00Z"}]`},
+		{label: "Fail: seven decimals", data: `[{"currency":"USD","thbPerUnit":"36.1234567","updatedAt":"2026-10-01T09:00:00Z"}]`},
+		{label: "Fail: missing timestamp", data: `[{"currency":"USD","thbPerUnit":"36.5"}]`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			_, err := _exchangeRateRepository.ParseExchangeRates([]byte(c.data))
+
+			var target *_foodShopException.InvalidExchangeRateConfigError
+			assert.ErrorAs(t, err, &target)
+		})
+	}
+}
+
+func TestMoney_Convert(t *testing.T) {
+	cases := []struct {
+		label    string
+		in       domain.Money
+		currency domain.Currency
+		rate     domain.ExchangeRate
+		mode     domain.RoundingMode
+		expected string
+	}{
+		{label: "USD half-up", in: domain.THB(100), currency: domain.CurrencyUSD, rate: 36_500_000, mode: domain.RoundHalfUp, expected: "2.74 USD"},
+		{label: "USD floor", in: domain.THB(100), currency: domain.CurrencyUSD, rate: 36_500_000, mode: domain.RoundFloor, expected: "2.73 USD"},
+		{label: "JPY whole yen", in: satang(4999), currency: domain.CurrencyJPY, rate: 241_500, mode: domain.RoundHalfUp, expected: "207 JPY"},
+		{label: "Negative keeps its sign", in: satang(-50), currency: domain.CurrencyUSD, rate: 36_500_000, mode: domain.RoundHalfUp, expected: "-0.01 USD"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			got, err := c.in.Convert(c.currency, c.rate, c.mode)
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, got.String())
+		})
+	}
+
+	_, err := domain.THB(1).Convert(domain.CurrencyUSD, 0, domain.RoundHalfUp)
+	assert.ErrorIs(t, err, domain.ErrDivisionByZero)
+}
+
+func TestAmount_JSON(t *testing.T) {
+	amount := domain.Amount{Minor: 414, Currency: domain.CurrencyJPY}
+
+	data, err := json.Marshal(amount)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"amount":"414","currency":"JPY"}`, string(data))
+
+	var decoded domain.Amount
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, amount, decoded)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"amount":"414.5","currency":"JPY"}`), &decoded))
+	assert.Error(t, json.Unmarshal([]byte(`{"amount":"1","currency":"XYZ"}`), &decoded))
+}
@@ -34,8 +34,7 @@ func TestQuoteOrder_DiscountPolicies(t *testing.T) {
 		{
 			label: "Pair: GREEN(4) => 2 bundles",
 			in: _foodShopModel.PurchasingRequest{
-				Items:  map[string]int{"GREEN": 4},
-				Member: false,
+				Items: map[string]int{"GREEN": 4},
 			},
 			setupMenuMock: func(r *_foodShopRepository.FoodShopRepositoryMock) {
 				r.On("FindMenuItemByCode", _foodShopModel.MenuItemCode("GREEN")).
@@ -52,8 +51,7 @@ func TestQuoteOrder_DiscountPolicies(t *testing.T) {
 		{
 			label: "Pair: GREEN(3) => 1 bundle + remainder",
 			in: _foodShopModel.PurchasingRequest{
-				Items:  map[string]int{"GREEN": 3},
-				Member: false,
+				Items: map[string]int{"GREEN": 3},
 			},
 			setupMenuMock: func(r *_foodShopRepository.FoodShopRepositoryMock) {
 				r.On("FindMenuItemByCode", _foodShopModel.MenuItemCode("GREEN")).
@@ -69,8 +67,7 @@ func TestQuoteOrder_DiscountPolicies(t *testing.T) {
 		{
 			label: "Pair: PINK(2) => 1 bundle",
 			in: _foodShopModel.PurchasingRequest{
-				Items:  map[string]int{"PINK": 2},
-				Member: false,
+				Items: map[string]int{"PINK": 2},
 			},
 			setupMenuMock: func(r *_foodShopRepository.FoodShopRepositoryMock) {
 				r.On("FindMenuItemByCode", _foodShopModel.MenuItemCode("PINK")).
@@ -86,8 +83,7 @@ func TestQuoteOrder_DiscountPolicies(t *testing.T) {
 		{
 			label: "Pair: PINK(4) => 2 bundles",
 			in: _foodShopModel.PurchasingRequest{
-				Items:  map[string]int{"PINK": 4},
-				Member: false,
+				Items: map[string]int{"PINK": 4},
 			},
 			setupMenuMock: func(r *_foodShopRepository.FoodShopRepositoryMock) {
 				r.On("FindMenuItemByCode", _foodShopModel.MenuItemCode("PINK")).
@@ -103,8 +99,7 @@ func TestQuoteOrder_DiscountPolicies(t *testing.T) {
 		{
 			label: "Pair: GREEN(2)+ORANGE(2) => sum per code",
 			in: _foodShopModel.PurchasingRequest{
-				Items:  map[string]int{"GREEN": 2, "ORANGE": 2},
-				Member: false,
+				Items: map[string]int{"GREEN": 2, "ORANGE": 2},
 			},
 			setupMenuMock: func(r *_foodShopRepository.FoodShopRepositoryMock) {
 				r.On("FindMenuItemByCode", _foodShopModel.MenuItemCode("GREEN")).
@@ -123,8 +118,7 @@ func TestQuoteOrder_DiscountPolicies(t *testing.T) {
 		{
 			label: "Pair: RED(2) not eligible => 0 pair discount",
 			in: _foodShopModel.PurchasingRequest{
-				Items:  map[string]int{"RED": 2},
-				Member: false,
+				Items: map[string]int{"RED": 2},
 			},
 			setupMenuMock: func(r *_foodShopRepository.FoodShopRepositoryMock) {
 				r.On("FindMenuItemByCode", _foodShopModel.MenuItemCode("RED")).
@@ -140,8 +134,7 @@ func TestQuoteOrder_DiscountPolicies(t *testing.T) {
 		{
 			label: "No cross-code pairing: GREEN(1)+ORANGE(1) => 0 pair discount",
 			in: _foodShopModel.PurchasingRequest{
-				Items:  map[string]int{"GREEN": 1, "ORANGE": 1},
-				Member: false,
+				Items: map[string]int{"GREEN": 1, "ORANGE": 1},
 			},
 			setupMenuMock: func(r *_foodShopRepository.FoodShopRepositoryMock) {
 				r.On("FindMenuItemByCode", _foodShopModel.MenuItemCode("GREEN")).
@@ -161,10 +154,10 @@ func TestQuoteOrder_DiscountPolicies(t *testing.T) {
 			},
 		},
 		{
-			label: "Member without pair: RED(2), member=true => 10% off",
+			label: "Member without pair: RED(2), gold member => 10% off",
 			in: _foodShopModel.PurchasingRequest{
-				Items:  map[string]int{"RED": 2},
-				Member: true,
+				Items:    map[string]int{"RED": 2},
+				MemberID: goldMemberID,
 			},
 			setupMenuMock: func(r *_foodShopRepository.FoodShopRepositoryMock) {
 				r.On("FindMenuItemByCode", _foodShopModel.MenuItemCode("RED")).
@@ -180,10 +173,10 @@ func TestQuoteOrder_DiscountPolicies(t *testing.T) {
 			},
 		},
 		{
-			label: "Member + multi-code: GREEN(2)+RED(1), member=true => member stacks after pair on total",
+			label: "Member + multi-code: GREEN(2)+RED(1), gold member => member stacks after pair on total",
 			in: _foodShopModel.PurchasingRequest{
-				Items:  map[string]int{"GREEN": 2, "RED": 1},
-				Member: true,
+				Items:    map[string]int{"GREEN": 2, "RED": 1},
+				MemberID: goldMemberID,
 			},
 			setupMenuMock: func(r *_foodShopRepository.FoodShopRepositoryMock) {
 				r.On("FindMenuItemByCode", _foodShopModel.MenuItemCode("GREEN")).
@@ -211,6 +204,7 @@ func TestQuoteOrder_DiscountPolicies(t *testing.T) {
 			orderHistoryRepositoryMock.Test(t)
 
 			c.setupMenuMock(foodShopRepositoryMock)
+			expectDefaultPromotions(foodShopRepositoryMock)
 
 			orderHistoryRepositoryMock.
 				On("Add", mock.MatchedBy(func(entry _orderHistoryModel.OrderHistoryEntry) bool {
@@ -224,7 +218,7 @@ func TestQuoteOrder_DiscountPolicies(t *testing.T) {
 						return false
 					}
 
-					if entry.Member != c.in.Member {
+					if entry.MemberID != c.in.MemberID {
 						return false
 					}
 					if entry.Subtotal != c.expectedSubtotal {
@@ -257,6 +251,7 @@ func TestQuoteOrder_DiscountPolicies(t *testing.T) {
 			foodShopService := _foodShopService.NewFoodShopServiceImpl(
 				foodShopRepositoryMock,
 				orderHistoryRepositoryMock,
+				_foodShopService.WithMemberRepository(testMemberRepository()),
 			)
 
 			res, err := foodShopService.QuoteOrder(c.in)
@@ -0,0 +1,307 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopPromotion "github.com/TewApirat/food-shop/pkg/foodShop/promotion"
+	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
+	_orderHistoryRepository "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
+)
+
+const freeItemPromotions = `[
+	{"code":"BLUE3GET1","type":"BUY_X_GET_Y","title":"Buy 3 BLUE get 1 free","eligibleCodes":["BLUE"],"params":{"buyQty":3,"freeQty":1}},
+	{"code":"YELLOW3RD","type":"NTH_FREE","title":"Every 3rd YELLOW free","eligibleCodes":["YELLOW"],"params":{"nth":3}},
+	{"code":"ORANGE2SIDE","type":"BUY_X_GET_Y","title":"Buy 2 ORANGE get a RED or BLUE free","eligibleCodes":["ORANGE"],"params":{"buyQty":2,"freeQty":1,"rewardCodes":["RED","BLUE"]}}
+]`
+
+const autoAddPromotions = `[
+	{"code":"ORANGE2RED","type":"BUY_X_GET_Y","title":"Buy 2 ORANGE get a RED free","eligibleCodes":["ORANGE"],"params":{"buyQty":2,"freeQty":1,"rewardCodes":["RED"],"autoAddReward":true}}
+]`
+
+func TestQuoteOrder_FreeItemPromotions(t *testing.T) {
+	type tc struct {
+		label      string
+		promotions string
+		items      map[string]int
+
+		expectedSubtotal          domain.Money
+		expectedPromotionDiscount domain.Money
+		expectedFreeQty           map[_foodShopModel.MenuItemCode]int
+		expectedQty               map[_foodShopModel.MenuItemCode]int
+	}
+
+	cases := []tc{
+		{
+			label:                     "Buy 3 get 1: BLUE(4) => 1 free",
+			promotions:                freeItemPromotions,
+			items:                     map[string]int{"BLUE": 4},
+			expectedSubtotal:          domain.THB(120),
+			expectedPromotionDiscount: domain.THB(30),
+			expectedFreeQty:           map[_foodShopModel.MenuItemCode]int{"BLUE": 1},
+		},
+		{
+			label:                     "Buy 3 get 1: BLUE(3) => nothing free yet",
+			promotions:                freeItemPromotions,
+			items:                     map[string]int{"BLUE": 3},
+			expectedSubtotal:          domain.THB(90),
+			expectedPromotionDiscount: domain.THB(0),
+			expectedFreeQty:           map[_foodShopModel.MenuItemCode]int{},
+		},
+		{
+			label:                     "Buy 3 get 1: BLUE(9) => 2 free",
+			promotions:                freeItemPromotions,
+			items:                     map[string]int{"BLUE": 9},
+			expectedSubtotal:          domain.THB(270),
+			expectedPromotionDiscount: domain.THB(60),
+			expectedFreeQty:           map[_foodShopModel.MenuItemCode]int{"BLUE": 2},
+		},
+		{
+			label:                     "Every 3rd: YELLOW(7) => 2 free",
+			promotions:                freeItemPromotions,
+			items:                     map[string]int{"YELLOW": 7},
+			expectedSubtotal:          domain.THB(350),
+			expectedPromotionDiscount: domain.THB(100),
+			expectedFreeQty:           map[_foodShopModel.MenuItemCode]int{"YELLOW": 2},
+		},
+		{
+			label:                     "Cross-code reward: ORANGE(2)+RED(1)+BLUE(1) => cheapest reward (BLUE) free",
+			promotions:                freeItemPromotions,
+			items:                     map[string]int{"ORANGE": 2, "RED": 1, "BLUE": 1},
+			expectedSubtotal:          domain.THB(320),
+			expectedPromotionDiscount: domain.THB(30),
+			expectedFreeQty:           map[_foodShopModel.MenuItemCode]int{"BLUE": 1},
+		},
+		{
+			label:                     "Cross-code reward: ORANGE(4)+RED(1) => only the reward in the cart is free",
+			promotions:                freeItemPromotions,
+			items:                     map[string]int{"ORANGE": 4, "RED": 1},
+			expectedSubtotal:          domain.THB(530),
+			expectedPromotionDiscount: domain.THB(50),
+			expectedFreeQty:           map[_foodShopModel.MenuItemCode]int{"RED": 1},
+		},
+		{
+			label:                     "Auto-add reward: ORANGE(4) => RED(2) added for free",
+			promotions:                autoAddPromotions,
+			items:                     map[string]int{"ORANGE": 4},
+			expectedSubtotal:          domain.THB(580),
+			expectedPromotionDiscount: domain.THB(100),
+			expectedFreeQty:           map[_foodShopModel.MenuItemCode]int{"RED": 2},
+			expectedQty:               map[_foodShopModel.MenuItemCode]int{"ORANGE": 4, "RED": 2},
+		},
+		{
+			label:                     "Auto-add reward: ORANGE(2)+RED(1) => RED already in cart, nothing added",
+			promotions:                autoAddPromotions,
+			items:                     map[string]int{"ORANGE": 2, "RED": 1},
+			expectedSubtotal:          domain.THB(290),
+			expectedPromotionDiscount: domain.THB(50),
+			expectedFreeQty:           map[_foodShopModel.MenuItemCode]int{"RED": 1},
+			expectedQty:               map[_foodShopModel.MenuItemCode]int{"ORANGE": 2, "RED": 1},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			promotions, err := _foodShopRepository.ParsePromotions([]byte(c.promotions))
+			assert.NoError(t, err)
+
+			foodShopRepositoryMock := new(_foodShopRepository.FoodShopRepositoryMock)
+			orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+
+			foodShopRepositoryMock.Test(t)
+			orderHistoryRepositoryMock.Test(t)
+
+			for code, item := range _foodShopRepository.DefaultMenu() {
+				foodShopRepositoryMock.On("FindMenuItemByCode", code).Return(item, nil).Maybe()
+			}
+			foodShopRepositoryMock.On("ListPromotions").Return(promotions, nil).Once()
+			orderHistoryRepositoryMock.On("Add", mock.Anything).Return(nil).Once()
+
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				foodShopRepositoryMock,
+				orderHistoryRepositoryMock,
+			)
+
+			res, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{Items: c.items})
+			assert.NoError(t, err)
+
+			assert.Equal(t, c.expectedSubtotal, res.Subtotal)
+			assert.Equal(t, c.expectedPromotionDiscount, res.PromotionDiscount)
+			assert.Equal(t, c.expectedSubtotal.Sub(c.expectedPromotionDiscount), res.Total)
+
+			gotFree := make(map[_foodShopModel.MenuItemCode]int)
+			for _, ln := range res.Lines {
+				if ln.FreeQty > 0 {
+					gotFree[ln.Code] += ln.FreeQty
+				}
+			}
+			assert.Equal(t, c.expectedFreeQty, gotFree)
+
+			if c.expectedQty != nil {
+				assert.Equal(t, c.expectedQty, lineQtyMap(res.Lines))
+			}
+
+			orderHistoryRepositoryMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestQuoteOrder_FreeItemRewardOverlap(t *testing.T) {
+	type tc struct {
+		label string
+		items map[string]int
+
+		expectedPromotionDiscount domain.Money
+		expectedFreeQty           map[_foodShopModel.MenuItemCode]int
+	}
+
+	// The loader refuses overlapping codes, but a rule built in code can
+	// still list BLUE as both something to buy and the reward.
+	rule := _foodShopPromotion.NewFreeItemRule("BLUEPLUSBLUE", _foodShopModel.FreeItemPolicy{
+		EligibleCodes: map[_foodShopModel.MenuItemCode]bool{"GREEN": true, "BLUE": true},
+		BuyQty:        1,
+		FreeQty:       1,
+		RewardCodes:   map[_foodShopModel.MenuItemCode]bool{"BLUE": true},
+	})
+
+	cases := []tc{
+		{
+			label:                     "BLUE(1) cannot pay for itself",
+			items:                     map[string]int{"BLUE": 1},
+			expectedPromotionDiscount: domain.THB(0),
+			expectedFreeQty:           map[_foodShopModel.MenuItemCode]int{},
+		},
+		{
+			label:                     "BLUE(2) => one paid, one free",
+			items:                     map[string]int{"BLUE": 2},
+			expectedPromotionDiscount: domain.THB(30),
+			expectedFreeQty:           map[_foodShopModel.MenuItemCode]int{"BLUE": 1},
+		},
+		{
+			label:                     "BLUE(3)+GREEN(1) => two free, paid by GREEN and BLUE",
+			items:                     map[string]int{"BLUE": 3, "GREEN": 1},
+			expectedPromotionDiscount: domain.THB(60),
+			expectedFreeQty:           map[_foodShopModel.MenuItemCode]int{"BLUE": 2},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				_foodShopRepository.NewFoodShopRepositoryDefault(),
+				_orderHistoryRepository.NewOrderHistoryRepositoryImpl(),
+				_foodShopService.WithPromotionRules(rule),
+			)
+
+			res, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{Items: c.items})
+			assert.NoError(t, err)
+			assert.Equal(t, c.expectedPromotionDiscount, res.PromotionDiscount)
+
+			gotFree := make(map[_foodShopModel.MenuItemCode]int)
+			for _, ln := range res.Lines {
+				if ln.FreeQty > 0 {
+					gotFree[ln.Code] += ln.FreeQty
+				}
+			}
+			assert.Equal(t, c.expectedFreeQty, gotFree)
+		})
+	}
+}
+
+func TestQuoteOrder_FreeRewardLineNet(t *testing.T) {
+	promotions, err := _foodShopRepository.ParsePromotions([]byte(autoAddPromotions))
+	assert.NoError(t, err)
+	foodShopService := _foodShopService.NewFoodShopServiceImpl(
+		_foodShopRepository.NewFoodShopRepositoryImpl(_foodShopRepository.DefaultMenu(), promotions),
+		_orderHistoryRepository.NewOrderHistoryRepositoryImpl(),
+	)
+
+	res, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{Items: map[string]int{"ORANGE": 2}})
+	assert.NoError(t, err)
+	if assert.Len(t, res.Lines, 2) {
+		assert.Equal(t, _foodShopModel.MenuItemCode("ORANGE"), res.Lines[0].Code)
+		assert.Empty(t, res.Lines[0].Discounts, "the paid ORANGE keep their price")
+		assert.Equal(t, domain.THB(240), res.Lines[0].NetTotal)
+
+		assert.Equal(t, _foodShopModel.MenuItemCode("RED"), res.Lines[1].Code)
+		assert.Equal(t, []_foodShopModel.LineDiscount{{Code: "ORANGE2RED", Amount: domain.THB(50)}}, res.Lines[1].Discounts)
+		assert.Equal(t, domain.THB(0), res.Lines[1].NetTotal, "the free RED line comes out free")
+	}
+}
+
+func TestQuoteOrder_AutoAddRewardUnavailable(t *testing.T) {
+	type tc struct {
+		label       string
+		now         time.Time
+		retireRed   bool
+		expectedQty map[_foodShopModel.MenuItemCode]int
+	}
+
+	// SUNRISE is served 06:00-11:00.
+	const promotions = `[
+		{"code":"ORANGE2RED","type":"BUY_X_GET_Y","eligibleCodes":["ORANGE"],"params":{"buyQty":2,"freeQty":1,"rewardCodes":["RED"],"autoAddReward":true}},
+		{"code":"ORANGE2SUNRISE","type":"BUY_X_GET_Y","eligibleCodes":["ORANGE"],"params":{"buyQty":2,"freeQty":1,"rewardCodes":["SUNRISE"],"autoAddReward":true}}
+	]`
+
+	cases := []tc{
+		{
+			label:       "Both rewards can be ordered: both added",
+			now:         bkk(17, 8, 0),
+			expectedQty: map[_foodShopModel.MenuItemCode]int{"ORANGE": 2, "RED": 1, "SUNRISE": 1},
+		},
+		{
+			label:       "SUNRISE outside its hours: left out",
+			now:         bkk(17, 12, 0),
+			expectedQty: map[_foodShopModel.MenuItemCode]int{"ORANGE": 2, "RED": 1},
+		},
+		{
+			label:       "RED retired: left out",
+			now:         bkk(17, 8, 0),
+			retireRed:   true,
+			expectedQty: map[_foodShopModel.MenuItemCode]int{"ORANGE": 2, "SUNRISE": 1},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			parsed, err := _foodShopRepository.ParsePromotions([]byte(promotions))
+			assert.NoError(t, err)
+			foodShopRepository := _foodShopRepository.NewFoodShopRepositoryImpl(_foodShopRepository.DefaultMenu(), parsed)
+			if c.retireRed {
+				_, err := foodShopRepository.RetireMenuItem("RED", c.now.Add(-time.Hour))
+				assert.NoError(t, err)
+			}
+
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				foodShopRepository,
+				_orderHistoryRepository.NewOrderHistoryRepositoryImpl(),
+				_foodShopService.WithClock(domain.FixedClock(c.now)),
+			)
+
+			res, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{Items: map[string]int{"ORANGE": 2}})
+			assert.NoError(t, err)
+			assert.Equal(t, c.expectedQty, lineQtyMap(res.Lines))
+		})
+	}
+}
+
+func TestParsePromotions_InvalidFreeItem(t *testing.T) {
+	cases := []string{
+		`[{"code":"X","type":"BUY_X_GET_Y","params":{"buyQty":3,"freeQty":1}}]`,
+		`[{"code":"X","type":"BUY_X_GET_Y","eligibleCodes":["BLUE"],"params":{"buyQty":0,"freeQty":1}}]`,
+		`[{"code":"X","type":"BUY_X_GET_Y","eligibleCodes":["BLUE"],"params":{"buyQty":2,"freeQty":1,"rewardCodes":["blue"]}}]`,
+		`[{"code":"X","type":"BUY_X_GET_Y","eligibleCodes":["ORANGE"],"params":{"buyQty":2,"freeQty":1,"autoAddReward":true}}]`,
+		`[{"code":"X","type":"NTH_FREE","eligibleCodes":["YELLOW"],"params":{"nth":1}}]`,
+	}
+
+	for _, data := range cases {
+		_, err := _foodShopRepository.ParsePromotions([]byte(data))
+		assert.Error(t, err, data)
+	}
+}
@@ -0,0 +1,147 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	_couponModel "github.com/TewApirat/food-shop/pkg/coupon/model"
+	_couponRepository "github.com/TewApirat/food-shop/pkg/coupon/repository"
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+)
+
+func TestQuoteOrder_LineDiscountAllocation(t *testing.T) {
+	type line struct {
+		discounts []_foodShopModel.LineDiscount
+		net       domain.Money
+	}
+	type tc struct {
+		label    string
+		in       _foodShopModel.PurchasingRequest
+		expected map[_foodShopModel.MenuItemCode]line
+	}
+
+	cases := []tc{
+		{
+			label: "Pair stays on GREEN, member spread over what each line still owes",
+			in:    _foodShopModel.PurchasingRequest{Items: map[string]int{"GREEN": 2, "RED": 1}, MemberID: goldMemberID},
+			expected: map[_foodShopModel.MenuItemCode]line{
+				"GREEN": {
+					discounts: []_foodShopModel.LineDiscount{{Code: "PAIR", Amount: domain.THB(4)}, {Code: "MEMBER", Amount: satang(760)}},
+					net:       satang(6840),
+				},
+				"RED": {
+					discounts: []_foodShopModel.LineDiscount{{Code: "MEMBER", Amount: domain.THB(5)}},
+					net:       domain.THB(45),
+				},
+			},
+		},
+		{
+			label: "Remainder satang: TEN over 30/40/50 => 2.50/3.33/4.17, largest remainder gets the extra satang",
+			in:    _foodShopModel.PurchasingRequest{Items: map[string]int{"BLUE": 1, "GREEN": 1, "RED": 1}, Coupons: []string{"TEN"}},
+			expected: map[_foodShopModel.MenuItemCode]line{
+				"BLUE":  {discounts: []_foodShopModel.LineDiscount{{Code: "TEN", Amount: satang(250)}}, net: satang(2750)},
+				"GREEN": {discounts: []_foodShopModel.LineDiscount{{Code: "TEN", Amount: satang(333)}}, net: satang(3667)},
+				"RED":   {discounts: []_foodShopModel.LineDiscount{{Code: "TEN", Amount: satang(417)}}, net: satang(4583)},
+			},
+		},
+		{
+			label: "Eligible-only coupon: ORANGE10 only touches ORANGE",
+			in:    _foodShopModel.PurchasingRequest{Items: map[string]int{"ORANGE": 1, "RED": 1}, Coupons: []string{"ORANGE10"}},
+			expected: map[_foodShopModel.MenuItemCode]line{
+				"ORANGE": {discounts: []_foodShopModel.LineDiscount{{Code: "ORANGE10", Amount: domain.THB(12)}}, net: domain.THB(108)},
+				"RED":    {net: domain.THB(50)},
+			},
+		},
+		{
+			label: "Fixed coupon larger than the bill: every line goes to zero",
+			in:    _foodShopModel.PurchasingRequest{Items: map[string]int{"BLUE": 1, "RED": 2}, Coupons: []string{"BIGFIXED"}},
+			expected: map[_foodShopModel.MenuItemCode]line{
+				"BLUE": {discounts: []_foodShopModel.LineDiscount{{Code: "BIGFIXED", Amount: domain.THB(30)}}, net: domain.THB(0)},
+				"RED":  {discounts: []_foodShopModel.LineDiscount{{Code: "BIGFIXED", Amount: domain.THB(100)}}, net: domain.THB(0)},
+			},
+		},
+	}
+
+	coupons := append(testCoupons(), _couponModel.Coupon{Code: "TEN", Type: _couponModel.CouponTypeFixed, Amount: domain.THB(10)})
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			foodShopService, orderHistoryRepositoryMock := newCouponTestService(t,
+				_couponRepository.NewCouponRepositoryImpl(coupons))
+			orderHistoryRepositoryMock.On("Add", mock.Anything).Return(nil).Once()
+
+			res, err := foodShopService.QuoteOrder(c.in)
+			assert.NoError(t, err)
+
+			assert.Len(t, res.Lines, len(c.expected))
+			var netSum domain.Money
+			for _, ln := range res.Lines {
+				expected := c.expected[ln.Code]
+				assert.Equal(t, expected.discounts, ln.Discounts, ln.Code)
+				assert.Equal(t, expected.net, ln.NetTotal, ln.Code)
+				netSum = netSum.Add(ln.NetTotal)
+			}
+			assert.Equal(t, res.Total, netSum)
+
+			orderHistoryRepositoryMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestQuoteOrder_LineNetTotalsAddUpToTotal(t *testing.T) {
+	requests := []_foodShopModel.PurchasingRequest{
+		{Items: map[string]int{"GREEN": 3, "PINK": 5, "ORANGE": 1}, MemberID: "P001"},
+		{Items: map[string]int{"green": 1, "GREEN": 2, "BLUE": 7}, MemberID: "S001", Coupons: []string{"TEN"}},
+		{Items: map[string]int{"ORANGE": 7, "PURPLE": 1, "YELLOW": 3}, MemberID: goldMemberID, Coupons: []string{"FIFTY", "ORANGE10"}},
+		{Items: map[string]int{"BLUE": 1}, MemberID: "P001", Coupons: []string{"TEN"}},
+	}
+
+	coupons := append(testCoupons(), _couponModel.Coupon{Code: "TEN", Type: _couponModel.CouponTypeFixed, Amount: domain.THB(10)})
+
+	for _, req := range requests {
+		foodShopService, orderHistoryRepositoryMock := newCouponTestService(t,
+			_couponRepository.NewCouponRepositoryImpl(coupons))
+		orderHistoryRepositoryMock.On("Add", mock.Anything).Return(nil).Once()
+
+		res, err := foodShopService.QuoteOrder(req)
+		assert.NoError(t, err)
+
+		var netSum domain.Money
+		for _, ln := range res.Lines {
+			lineSum := ln.NetTotal
+			for _, discount := range ln.Discounts {
+				assert.Greater(t, discount.Amount, domain.Money(0))
+				lineSum = lineSum.Add(discount.Amount)
+			}
+			assert.Equal(t, ln.LineTotal, lineSum, ln.Code)
+			assert.GreaterOrEqual(t, ln.NetTotal, domain.Money(0), ln.Code)
+			netSum = netSum.Add(ln.NetTotal)
+		}
+		assert.Equal(t, res.Total, netSum, req.Items)
+	}
+}
+
+func TestMoney_Allocate(t *testing.T) {
+	cases := []struct {
+		label    string
+		amount   domain.Money
+		weights  []domain.Money
+		expected []domain.Money
+	}{
+		{label: "Even split", amount: 90, weights: []domain.Money{1, 1, 1}, expected: []domain.Money{30, 30, 30}},
+		{label: "Tie on remainders: earlier parts first", amount: 100, weights: []domain.Money{1, 1, 1}, expected: []domain.Money{34, 33, 33}},
+		{label: "Largest remainder wins", amount: 1000, weights: []domain.Money{30, 40, 50}, expected: []domain.Money{250, 333, 417}},
+		{label: "Zero weight gets nothing", amount: 5, weights: []domain.Money{0, 2, 3}, expected: []domain.Money{0, 2, 3}},
+		{label: "No weight: all to the first part", amount: 7, weights: []domain.Money{0, 0}, expected: []domain.Money{7, 0}},
+		{label: "No parts", amount: 7, weights: nil, expected: []domain.Money{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			assert.Equal(t, c.expected, c.amount.Allocate(c.weights))
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopException "github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
+	_memberModel "github.com/TewApirat/food-shop/pkg/member/model"
+	_orderHistoryModel "github.com/TewApirat/food-shop/pkg/orderHistory/model"
+	_orderHistoryRepository "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
+)
+
+var memberTestNow = time.Date(2026, time.October, 14, 12, 0, 0, 0, time.UTC)
+
+func TestQuoteOrder_MemberTiers(t *testing.T) {
+	type tc struct {
+		label                  string
+		memberID               string
+		expectedMemberID       string
+		expectedTier           _memberModel.MemberTier
+		expectedMemberDiscount domain.Money
+		expectedTotal          domain.Money
+	}
+
+	// RED(2) = 100, no pair discount applies.
+	cases := []tc{
+		{
+			label:                  "Walk-in: no member ID, no member discount",
+			expectedMemberDiscount: domain.THB(0),
+			expectedTotal:          domain.THB(100),
+		},
+		{
+			label:                  "Silver: 5% off",
+			memberID:               "S001",
+			expectedMemberID:       "S001",
+			expectedTier:           _memberModel.MemberTierSilver,
+			expectedMemberDiscount: domain.THB(5),
+			expectedTotal:          domain.THB(95),
+		},
+		{
+			label:                  "Gold: 10% off, ID is trimmed and upper-cased",
+			memberID:               " g001 ",
+			expectedMemberID:       goldMemberID,
+			expectedTier:           _memberModel.MemberTierGold,
+			expectedMemberDiscount: domain.THB(10),
+			expectedTotal:          domain.THB(90),
+		},
+		{
+			label:                  "Platinum by card number: 15% off, history keeps the member ID",
+			memberID:               "5003",
+			expectedMemberID:       "P001",
+			expectedTier:           _memberModel.MemberTierPlatinum,
+			expectedMemberDiscount: domain.THB(15),
+			expectedTotal:          domain.THB(85),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			foodShopRepositoryMock := new(_foodShopRepository.FoodShopRepositoryMock)
+			orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+
+			foodShopRepositoryMock.Test(t)
+			orderHistoryRepositoryMock.Test(t)
+
+			foodShopRepositoryMock.On("FindMenuItemByCode", _foodShopModel.MenuItemCode("RED")).
+				Return(_foodShopModel.MenuItem{Code: "RED", Name: "Red set", Price: domain.THB(50)}, nil).
+				Once()
+			expectDefaultPromotions(foodShopRepositoryMock)
+
+			orderHistoryRepositoryMock.
+				On("Add", mock.MatchedBy(func(entry _orderHistoryModel.OrderHistoryEntry) bool {
+					return entry.MemberID == c.expectedMemberID &&
+						entry.MemberTier == c.expectedTier &&
+						entry.MemberDiscount == c.expectedMemberDiscount
+				})).
+				Return(nil).
+				Once()
+
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				foodShopRepositoryMock,
+				orderHistoryRepositoryMock,
+				_foodShopService.WithMemberRepository(testMemberRepository()),
+				_foodShopService.WithClock(domain.FixedClock(memberTestNow)),
+			)
+
+			res, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{
+				Items:    map[string]int{"RED": 2},
+				MemberID: c.memberID,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, c.expectedMemberID, res.MemberID)
+			assert.Equal(t, c.expectedTier, res.MemberTier)
+			assert.Equal(t, c.expectedMemberDiscount, res.MemberDiscount)
+			assert.Equal(t, c.expectedTotal, res.Total)
+
+			foodShopRepositoryMock.AssertExpectations(t)
+			orderHistoryRepositoryMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestQuoteOrder_MemberFail(t *testing.T) {
+	type tc struct {
+		label        string
+		memberID     string
+		withoutRepo  bool
+		expectsError func(t *testing.T, err error)
+	}
+
+	cases := []tc{
+		{
+			label:    "Fail: unknown member",
+			memberID: "NOPE",
+			expectsError: func(t *testing.T, err error) {
+				var target *_foodShopException.UnknownMemberError
+				assert.ErrorAs(t, err, &target)
+				assert.Equal(t, "NOPE", target.ID)
+			},
+		},
+		{
+			label:       "Fail: no member repository configured",
+			memberID:    goldMemberID,
+			withoutRepo: true,
+			expectsError: func(t *testing.T, err error) {
+				var target *_foodShopException.UnknownMemberError
+				assert.ErrorAs(t, err, &target)
+			},
+		},
+		{
+			label:    "Fail: expired membership",
+			memberID: "E001",
+			expectsError: func(t *testing.T, err error) {
+				var target *_foodShopException.ExpiredMemberError
+				assert.ErrorAs(t, err, &target)
+				assert.Equal(t, "E001", target.ID)
+			},
+		},
+		{
+			label:    "Fail: suspended membership",
+			memberID: "X001",
+			expectsError: func(t *testing.T, err error) {
+				var target *_foodShopException.SuspendedMemberError
+				assert.ErrorAs(t, err, &target)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			foodShopRepositoryMock := new(_foodShopRepository.FoodShopRepositoryMock)
+			orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+
+			foodShopRepositoryMock.Test(t)
+			orderHistoryRepositoryMock.Test(t)
+
+			foodShopRepositoryMock.On("FindMenuItemByCode", _foodShopModel.MenuItemCode("RED")).
+				Return(_foodShopModel.MenuItem{Code: "RED", Name: "Red set", Price: domain.THB(50)}, nil).
+				Once()
+
+			opts := []_foodShopService.Option{_foodShopService.WithClock(domain.FixedClock(memberTestNow))}
+			if !c.withoutRepo {
+				opts = append(opts, _foodShopService.WithMemberRepository(testMemberRepository()))
+			}
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				foodShopRepositoryMock,
+				orderHistoryRepositoryMock,
+				opts...,
+			)
+
+			_, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{
+				Items:    map[string]int{"RED": 2},
+				MemberID: c.memberID,
+			})
+			assert.Error(t, err)
+			c.expectsError(t, err)
+
+			foodShopRepositoryMock.AssertExpectations(t)
+			orderHistoryRepositoryMock.AssertNotCalled(t, "Add", mock.Anything)
+		})
+	}
+}
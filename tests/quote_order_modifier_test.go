@@ -0,0 +1,253 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopException "github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
+	_orderHistoryModel "github.com/TewApirat/food-shop/pkg/orderHistory/model"
+	_orderHistoryRepository "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
+)
+
+// modifierTestMenu has GREEN (40) with a required size, up to two extras
+// and an optional no-rice discount, and BLUE (3) where no rice would take
+// the price below zero.
+func modifierTestMenu() map[_foodShopModel.MenuItemCode]_foodShopModel.MenuItem {
+	noRice := _foodShopModel.ModifierGroup{Code: "RICE", Name: "Rice", MaxSelections: 1, Options: []_foodShopModel.ModifierOption{
+		{Code: "NO_RICE", Name: "No rice", PriceDelta: domain.THB(-5)},
+	}}
+
+	return map[_foodShopModel.MenuItemCode]_foodShopModel.MenuItem{
+		"RED": {Code: "RED", Name: "Red set", Price: domain.THB(50)},
+		"GREEN": {Code: "GREEN", Name: "Green set", Price: domain.THB(40), Modifiers: []_foodShopModel.ModifierGroup{
+			{Code: "SIZE", Name: "Size", MinSelections: 1, MaxSelections: 1, Options: []_foodShopModel.ModifierOption{
+				{Code: "REGULAR", Name: "Regular"},
+				{Code: "LARGE", Name: "Large", PriceDelta: domain.THB(10)},
+			}},
+			{Code: "EXTRAS", Name: "Extras", MaxSelections: 2, Options: []_foodShopModel.ModifierOption{
+				{Code: "EGG", Name: "Egg", PriceDelta: domain.THB(10)},
+				{Code: "TOFU", Name: "Tofu", PriceDelta: domain.THB(15)},
+				{Code: "BASIL", Name: "Basil", PriceDelta: domain.THB(5)},
+			}},
+			noRice,
+		}},
+		"BLUE": {Code: "BLUE", Name: "Blue set", Price: domain.THB(3), Modifiers: []_foodShopModel.ModifierGroup{noRice}},
+	}
+}
+
+func greenOptions(lines []_foodShopModel.OrderLine) []_foodShopModel.SelectedOption {
+	for _, ln := range lines {
+		if ln.Code == "GREEN" {
+			return ln.Options
+		}
+	}
+	return nil
+}
+
+func TestQuoteOrder_Modifiers(t *testing.T) {
+	type tc struct {
+		label string
+		in    _foodShopModel.PurchasingRequest
+
+		expectedUnitPrice domain.Money
+		expectedOptions   []_foodShopModel.SelectedOption
+		expectedSubtotal  domain.Money
+		expectedTotal     domain.Money
+	}
+
+	large := _foodShopModel.SelectedOption{Group: "SIZE", Code: "LARGE", Name: "Large", PriceDelta: domain.THB(10)}
+	regular := _foodShopModel.SelectedOption{Group: "SIZE", Code: "REGULAR", Name: "Regular"}
+	egg := _foodShopModel.SelectedOption{Group: "EXTRAS", Code: "EGG", Name: "Egg", PriceDelta: domain.THB(10)}
+	tofu := _foodShopModel.SelectedOption{Group: "EXTRAS", Code: "TOFU", Name: "Tofu", PriceDelta: domain.THB(15)}
+	noRice := _foodShopModel.SelectedOption{Group: "RICE", Code: "NO_RICE", Name: "No rice", PriceDelta: domain.THB(-5)}
+
+	cases := []tc{
+		{
+			label: "Success: GREEN(2) large + egg is 60 each, pair 5% only on the 80 menu price",
+			in: _foodShopModel.PurchasingRequest{Lines: []_foodShopModel.RequestLine{
+				{Code: "GREEN", Qty: 2, Options: []string{"LARGE", "EGG"}},
+			}},
+			expectedUnitPrice: domain.THB(60),
+			expectedOptions:   []_foodShopModel.SelectedOption{large, egg},
+			expectedSubtotal:  domain.THB(120),
+			expectedTotal:     domain.THB(116),
+		},
+		{
+			label: "Success: options in any order and case come back in menu order",
+			in: _foodShopModel.PurchasingRequest{Lines: []_foodShopModel.RequestLine{
+				{Code: "green", Qty: 1, Options: []string{"tofu", " regular ", "Egg"}},
+			}},
+			expectedUnitPrice: domain.THB(65),
+			expectedOptions:   []_foodShopModel.SelectedOption{regular, egg, tofu},
+			expectedSubtotal:  domain.THB(65),
+			expectedTotal:     domain.THB(65),
+		},
+		{
+			label: "Success: a negative delta lowers the unit price",
+			in: _foodShopModel.PurchasingRequest{Lines: []_foodShopModel.RequestLine{
+				{Code: "GREEN", Qty: 2, Options: []string{"NO_RICE", "REGULAR"}},
+			}},
+			expectedUnitPrice: domain.THB(35),
+			expectedOptions:   []_foodShopModel.SelectedOption{regular, noRice},
+			expectedSubtotal:  domain.THB(70),
+			expectedTotal:     domain.THB(66),
+		},
+		{
+			label: "Success: items and lines on one request",
+			in: _foodShopModel.PurchasingRequest{
+				Items: map[string]int{"RED": 1},
+				Lines: []_foodShopModel.RequestLine{{Code: "GREEN", Qty: 2, Options: []string{"REGULAR"}}},
+			},
+			expectedUnitPrice: domain.THB(40),
+			expectedOptions:   []_foodShopModel.SelectedOption{regular},
+			expectedSubtotal:  domain.THB(130),
+			expectedTotal:     domain.THB(126),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			foodShopRepositoryMock := new(_foodShopRepository.FoodShopRepositoryMock)
+			orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+
+			foodShopRepositoryMock.Test(t)
+			orderHistoryRepositoryMock.Test(t)
+
+			for code, item := range modifierTestMenu() {
+				foodShopRepositoryMock.On("FindMenuItemByCode", code).Return(item, nil).Maybe()
+			}
+			expectDefaultPromotions(foodShopRepositoryMock)
+			orderHistoryRepositoryMock.
+				On("Add", mock.MatchedBy(func(entry _orderHistoryModel.OrderHistoryEntry) bool {
+					return assert.ObjectsAreEqual(c.expectedOptions, greenOptions(entry.Line))
+				})).
+				Return(nil).
+				Once()
+
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				foodShopRepositoryMock,
+				orderHistoryRepositoryMock,
+			)
+
+			res, err := foodShopService.QuoteOrder(c.in)
+			assert.NoError(t, err)
+
+			assert.Equal(t, c.expectedOptions, greenOptions(res.Lines))
+			for _, ln := range res.Lines {
+				if ln.Code == "GREEN" {
+					assert.Equal(t, c.expectedUnitPrice, ln.UnitPrice)
+					assert.Equal(t, c.expectedUnitPrice.MulInt(ln.Qty), ln.LineTotal)
+				}
+			}
+			assert.Equal(t, c.expectedSubtotal, res.Subtotal)
+			assert.Equal(t, c.expectedTotal, res.Total)
+
+			foodShopRepositoryMock.AssertExpectations(t)
+			orderHistoryRepositoryMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestQuoteOrder_ModifiersFail(t *testing.T) {
+	type tc struct {
+		label    string
+		in       _foodShopModel.PurchasingRequest
+		expected _foodShopException.InvalidModifierError
+	}
+
+	greenLine := func(options ...string) _foodShopModel.PurchasingRequest {
+		return _foodShopModel.PurchasingRequest{Lines: []_foodShopModel.RequestLine{{Code: "GREEN", Qty: 1, Options: options}}}
+	}
+
+	cases := []tc{
+		{
+			label:    "Fail: unknown option",
+			in:       greenLine("REGULAR", "xl"),
+			expected: _foodShopException.InvalidModifierError{Code: "GREEN", Option: "XL", Reason: `unknown option "xl"`},
+		},
+		{
+			label:    "Fail: option chosen twice",
+			in:       greenLine("REGULAR", "regular"),
+			expected: _foodShopException.InvalidModifierError{Code: "GREEN", Option: "REGULAR", Reason: "option REGULAR chosen twice"},
+		},
+		{
+			label:    "Fail: required size missing",
+			in:       greenLine("EGG"),
+			expected: _foodShopException.InvalidModifierError{Code: "GREEN", Group: "SIZE", Reason: "choose at least 1 from Size"},
+		},
+		{
+			label:    "Fail: items map cannot skip a required group",
+			in:       _foodShopModel.PurchasingRequest{Items: map[string]int{"GREEN": 1}},
+			expected: _foodShopException.InvalidModifierError{Code: "GREEN", Group: "SIZE", Reason: "choose at least 1 from Size"},
+		},
+		{
+			label:    "Fail: two sizes",
+			in:       greenLine("REGULAR", "LARGE"),
+			expected: _foodShopException.InvalidModifierError{Code: "GREEN", Group: "SIZE", Reason: "choose at most 1 from Size"},
+		},
+		{
+			label:    "Fail: three extras",
+			in:       greenLine("REGULAR", "EGG", "TOFU", "BASIL"),
+			expected: _foodShopException.InvalidModifierError{Code: "GREEN", Group: "EXTRAS", Reason: "choose at most 2 from Extras"},
+		},
+		{
+			label:    "Fail: BLUE 3 with no rice -5",
+			in:       _foodShopModel.PurchasingRequest{Lines: []_foodShopModel.RequestLine{{Code: "BLUE", Qty: 1, Options: []string{"NO_RICE"}}}},
+			expected: _foodShopException.InvalidModifierError{Code: "BLUE", Reason: "the options take the price below zero"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			foodShopRepositoryMock := new(_foodShopRepository.FoodShopRepositoryMock)
+			orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+
+			foodShopRepositoryMock.Test(t)
+			orderHistoryRepositoryMock.Test(t)
+
+			for code, item := range modifierTestMenu() {
+				foodShopRepositoryMock.On("FindMenuItemByCode", code).Return(item, nil).Maybe()
+			}
+
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				foodShopRepositoryMock,
+				orderHistoryRepositoryMock,
+			)
+
+			_, err := foodShopService.QuoteOrder(c.in)
+
+			var target *_foodShopException.InvalidModifierError
+			if assert.ErrorAs(t, err, &target) {
+				assert.Equal(t, c.expected, *target)
+			}
+
+			foodShopRepositoryMock.AssertExpectations(t)
+			orderHistoryRepositoryMock.AssertNotCalled(t, "Add", mock.Anything)
+		})
+	}
+}
+
+func TestModifierGroupRule(t *testing.T) {
+	cases := []struct {
+		group    _foodShopModel.ModifierGroup
+		expected string
+	}{
+		{group: _foodShopModel.ModifierGroup{MinSelections: 1, MaxSelections: 1}, expected: "required, pick 1"},
+		{group: _foodShopModel.ModifierGroup{MaxSelections: 3}, expected: "optional, up to 3"},
+		{group: _foodShopModel.ModifierGroup{}, expected: "optional, any number"},
+		{group: _foodShopModel.ModifierGroup{MinSelections: 2}, expected: "required, at least 2"},
+		{group: _foodShopModel.ModifierGroup{MinSelections: 1, MaxSelections: 2}, expected: "required, up to 2"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.expected, func(t *testing.T) {
+			assert.Equal(t, c.expected, c.group.Rule())
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopException "github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
+	_orderHistoryRepository "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
+)
+
+func TestQuoteOrder_AmountOverflow(t *testing.T) {
+	type tc struct {
+		label    string
+		items    map[string]int
+		freeItem bool
+		opts     []_foodShopService.Option
+		expected _foodShopException.AmountOverflowError
+	}
+
+	cases := []tc{
+		{
+			label:    "Fail: RED(max int) overflows the line total",
+			items:    map[string]int{"RED": math.MaxInt},
+			expected: _foodShopException.AmountOverflowError{Step: "line RED"},
+		},
+		{
+			label:    "Fail: water(max int) + WATER(1) overflows the quantity of a free item",
+			items:    map[string]int{"water": math.MaxInt, "WATER": 1},
+			freeItem: true,
+			expected: _foodShopException.AmountOverflowError{Step: "quantity of WATER"},
+		},
+		{
+			label:    "Fail: RED(20001) 1,000,050 is over the default maximum",
+			items:    map[string]int{"RED": 20001},
+			expected: _foodShopException.AmountOverflowError{Step: "line RED", Limit: _foodShopService.DefaultMaxOrderValue},
+		},
+		{
+			label:    "Fail: RED(1)+BLUE(2) 110 is over a 100 maximum",
+			items:    map[string]int{"RED": 1, "BLUE": 2},
+			opts:     []_foodShopService.Option{_foodShopService.WithMaxOrderValue(domain.THB(100))},
+			expected: _foodShopException.AmountOverflowError{Step: "subtotal", Limit: domain.THB(100)},
+		},
+		{
+			label:    "Fail: no maximum still rejects overflow",
+			items:    map[string]int{"ORANGE": math.MaxInt / 100},
+			opts:     []_foodShopService.Option{_foodShopService.WithMaxOrderValue(0)},
+			expected: _foodShopException.AmountOverflowError{Step: "line ORANGE"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			foodShopRepositoryMock := new(_foodShopRepository.FoodShopRepositoryMock)
+			orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+
+			foodShopRepositoryMock.Test(t)
+			orderHistoryRepositoryMock.Test(t)
+
+			for code, item := range _foodShopRepository.DefaultMenu() {
+				foodShopRepositoryMock.On("FindMenuItemByCode", code).Return(item, nil).Maybe()
+			}
+			if c.freeItem {
+				foodShopRepositoryMock.On("FindMenuItemByCode", _foodShopModel.MenuItemCode("WATER")).
+					Return(_foodShopModel.MenuItem{Code: "WATER", Name: "Water"}, nil).
+					Twice()
+			}
+			foodShopRepositoryMock.On("ListPromotions").Return(_foodShopRepository.DefaultPromotions(), nil).Maybe()
+
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				foodShopRepositoryMock,
+				orderHistoryRepositoryMock,
+				c.opts...,
+			)
+
+			_, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{Items: c.items})
+
+			var target *_foodShopException.AmountOverflowError
+			if assert.ErrorAs(t, err, &target) {
+				assert.Equal(t, c.expected, *target)
+			}
+			assert.ErrorIs(t, err, domain.ErrAmountOverflow)
+
+			foodShopRepositoryMock.AssertExpectations(t)
+			orderHistoryRepositoryMock.AssertNotCalled(t, "Add", mock.Anything)
+		})
+	}
+}
+
+func TestQuoteOrder_NoMaxOrderValue(t *testing.T) {
+	foodShopRepositoryMock := new(_foodShopRepository.FoodShopRepositoryMock)
+	orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+
+	foodShopRepositoryMock.Test(t)
+	orderHistoryRepositoryMock.Test(t)
+
+	foodShopRepositoryMock.On("FindMenuItemByCode", _foodShopModel.MenuItemCode("RED")).
+		Return(_foodShopModel.MenuItem{Code: "RED", Name: "Red set", Price: domain.THB(50)}, nil).
+		Once()
+	expectDefaultPromotions(foodShopRepositoryMock)
+	orderHistoryRepositoryMock.On("Add", mock.Anything).Return(nil).Once()
+
+	foodShopService := _foodShopService.NewFoodShopServiceImpl(
+		foodShopRepositoryMock,
+		orderHistoryRepositoryMock,
+		_foodShopService.WithMaxOrderValue(0),
+	)
+
+	res, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{Items: map[string]int{"RED": 20001}})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.THB(1_000_050), res.Total)
+
+	foodShopRepositoryMock.AssertExpectations(t)
+	orderHistoryRepositoryMock.AssertExpectations(t)
+}
+
+func TestMoney_CheckedArithmetic(t *testing.T) {
+	type tc struct {
+		label       string
+		run         func() (domain.Money, error)
+		expected    domain.Money
+		expectedErr bool
+	}
+
+	maxMoney := domain.Money(math.MaxInt64)
+	minMoney := domain.Money(math.MinInt64)
+
+	cases := []tc{
+		{label: "Add", run: func() (domain.Money, error) { return satang(150).AddChecked(satang(-50)) }, expected: satang(100)},
+		{label: "Add up to max", run: func() (domain.Money, error) { return (maxMoney - 1).AddChecked(1) }, expected: maxMoney},
+		{label: "Fail: add past max", run: func() (domain.Money, error) { return maxMoney.AddChecked(1) }, expectedErr: true},
+		{label: "Fail: add past min", run: func() (domain.Money, error) { return minMoney.AddChecked(-1) }, expectedErr: true},
+		{label: "Sub", run: func() (domain.Money, error) { return satang(50).SubChecked(satang(150)) }, expected: satang(-100)},
+		{label: "Fail: sub past min", run: func() (domain.Money, error) { return minMoney.SubChecked(1) }, expectedErr: true},
+		{label: "Fail: sub past max", run: func() (domain.Money, error) { return maxMoney.SubChecked(-1) }, expectedErr: true},
+		{label: "MulInt", run: func() (domain.Money, error) { return domain.THB(50).MulIntChecked(3) }, expected: domain.THB(150)},
+		{label: "MulInt by zero", run: func() (domain.Money, error) { return maxMoney.MulIntChecked(0) }, expected: 0},
+		{label: "Fail: MulInt past max", run: func() (domain.Money, error) { return domain.THB(50).MulIntChecked(math.MaxInt) }, expectedErr: true},
+		{label: "Fail: MulInt min by -1", run: func() (domain.Money, error) { return minMoney.MulIntChecked(-1) }, expectedErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			got, err := c.run()
+			if c.expectedErr {
+				assert.ErrorIs(t, err, domain.ErrAmountOverflow)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, got)
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package tests
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopException "github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
+	_orderHistoryModel "github.com/TewApirat/food-shop/pkg/orderHistory/model"
+	_orderHistoryRepository "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
+)
+
+func TestQuoteOrder_FractionalRates(t *testing.T) {
+	type tc struct {
+		label      string
+		promotions string
+		in         _foodShopModel.PurchasingRequest
+		expected   []_foodShopModel.AppliedPromotion
+		total      domain.Money
+	}
+
+	cases := []tc{
+		{
+			label: "Pair 12.5%: GREEN(2) 80 => 10 off",
+			promotions: `[{"code":"PAIR","type":"PAIR","title":"Pair 12.5%","priority":10,"eligibleCodes":["GREEN"],
+				"params":{"discountPercent":12.5,"bundleSize":2}}]`,
+			in: _foodShopModel.PurchasingRequest{Items: map[string]int{"GREEN": 2, "RED": 1}},
+			expected: []_foodShopModel.AppliedPromotion{
+				{
+					Code:        "PAIR",
+					Items:       map[_foodShopModel.MenuItemCode]int{"GREEN": 2},
+					Base:        domain.THB(80),
+					Rate:        domain.BasisPoints(1250),
+					Discount:    domain.THB(10),
+					Explanation: "12.5% off bundles of 2: 2 x GREEN (80.00 THB)",
+				},
+			},
+			total: domain.THB(120),
+		},
+		{
+			label: `Spend tier "2.25%" as a string: RED(3) 150 => 3.375 floors to 3.37`,
+			promotions: `[{"code":"SPEND","type":"SPEND_TIER","title":"Spend 100","priority":10,
+				"params":{"tiers":[{"minSpend":100,"discountPercent":"2.25%"}]}}]`,
+			in: _foodShopModel.PurchasingRequest{Items: map[string]int{"RED": 3}},
+			expected: []_foodShopModel.AppliedPromotion{
+				{
+					Code:        "SPEND",
+					Base:        domain.THB(150),
+					Rate:        domain.BasisPoints(225),
+					Discount:    satang(337),
+					Explanation: "spent 150.00 THB, reached the 100.00 THB tier: 2.25% off",
+				},
+			},
+			total: satang(14663),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			promotions, err := _foodShopRepository.ParsePromotions([]byte(c.promotions))
+			assert.NoError(t, err)
+
+			foodShopRepositoryMock := new(_foodShopRepository.FoodShopRepositoryMock)
+			orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+
+			foodShopRepositoryMock.Test(t)
+			orderHistoryRepositoryMock.Test(t)
+
+			for code, item := range _foodShopRepository.DefaultMenu() {
+				foodShopRepositoryMock.On("FindMenuItemByCode", code).Return(item, nil).Maybe()
+			}
+			foodShopRepositoryMock.On("ListPromotions").Return(promotions, nil).Once()
+			orderHistoryRepositoryMock.
+				On("Add", mock.MatchedBy(func(entry _orderHistoryModel.OrderHistoryEntry) bool {
+					return entry.Total == c.total && assert.ObjectsAreEqual(c.expected, entry.AppliedPromotions)
+				})).
+				Return(nil).
+				Once()
+
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				foodShopRepositoryMock,
+				orderHistoryRepositoryMock,
+			)
+
+			res, err := foodShopService.QuoteOrder(c.in)
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, res.AppliedPromotions)
+			assert.Equal(t, c.total, res.Total)
+
+			foodShopRepositoryMock.AssertExpectations(t)
+			orderHistoryRepositoryMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestParsePromotions_InvalidRate(t *testing.T) {
+	cases := []struct {
+		label string
+		data  string
+	}{
+		{label: "Fail: three decimals", data: `[{"code":"X","type":"PAIR","eligibleCodes":["RED"],"params":{"discountPercent":12.555,"bundleSize":2}}]`},
+		{label: "Fail: just over 100", data: `[{"code":"X","type":"PAIR","eligibleCodes":["RED"],"params":{"discountPercent":100.01,"bundleSize":2}}]`},
+		{label: "Fail: negative", data: `[{"code":"X","type":"PAIR","eligibleCodes":["RED"],"params":{"discountPercent":-0.5,"bundleSize":2}}]`},
+		{label: "Fail: not a number", data: `[{"code":"X","type":"PAIR","eligibleCodes":["RED"],"params":{"discountPercent":"half","bundleSize":2}}]`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			_, err := _foodShopRepository.ParsePromotions([]byte(c.data))
+
+			var target *_foodShopException.InvalidPromotionConfigError
+			assert.ErrorAs(t, err, &target)
+		})
+	}
+}
+
+func TestRate_ParseAndString(t *testing.T) {
+	cases := []struct {
+		in       string
+		expected domain.Rate
+		text     string
+	}{
+		{in: "5", expected: domain.PercentRate(5), text: "5%"},
+		{in: "12.5", expected: domain.BasisPoints(1250), text: "12.5%"},
+		{in: " 2.25% ", expected: domain.BasisPoints(225), text: "2.25%"},
+		{in: "0.05", expected: domain.BasisPoints(5), text: "0.05%"},
+		{in: "-7.1", expected: domain.BasisPoints(-710), text: "-7.1%"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.in, func(t *testing.T) {
+			got, err := domain.ParseRate(c.in)
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, got)
+			assert.Equal(t, c.text, got.String())
+		})
+	}
+
+	for _, in := range []string{"", "%", "1.", "1.234", "abc", "1.-5"} {
+		_, err := domain.ParseRate(in)
+		assert.Error(t, err, in)
+	}
+
+	withNull := struct {
+		Rate domain.Rate `json:"rate"`
+	}{Rate: domain.PercentRate(5)}
+	assert.NoError(t, json.Unmarshal([]byte(`{"rate":null}`), &withNull))
+	assert.Equal(t, domain.PercentRate(5), withNull.Rate, "null leaves the rate alone")
+}
+
+func TestMoney_MulRateAndDivRate(t *testing.T) {
+	type tc struct {
+		label       string
+		run         func() (domain.Money, error)
+		expected    domain.Money
+		expectedErr error
+	}
+
+	cases := []tc{
+		{
+			label:    "12.5% of 99.99 floors to 12.49",
+			run:      func() (domain.Money, error) { return satang(9999).MulRate(domain.BasisPoints(1250), domain.RoundFloor) },
+			expected: satang(1249),
+		},
+		{
+			label: "12.5% of 99.99 half-even to 12.50",
+			run: func() (domain.Money, error) {
+				return satang(9999).MulRate(domain.BasisPoints(1250), domain.RoundHalfEven)
+			},
+			expected: satang(1250),
+		},
+		{
+			label:    "Negative half-up: 50% of -0.05 is -0.03",
+			run:      func() (domain.Money, error) { return satang(-5).MulRate(domain.PercentRate(50), domain.RoundHalfUp) },
+			expected: satang(-3),
+		},
+		{
+			label: "No overflow in the intermediate product",
+			run: func() (domain.Money, error) {
+				return domain.Money(math.MaxInt64).MulRate(domain.PercentRate(100), domain.RoundFloor)
+			},
+			expected: domain.Money(math.MaxInt64),
+		},
+		{
+			label: "Min int64 at 100%",
+			run: func() (domain.Money, error) {
+				return domain.Money(math.MinInt64).MulRate(domain.PercentRate(100), domain.RoundFloor)
+			},
+			expected: domain.Money(math.MinInt64),
+		},
+		{
+			label: "Fail: 200% of max overflows",
+			run: func() (domain.Money, error) {
+				return domain.Money(math.MaxInt64).MulRate(domain.PercentRate(200), domain.RoundFloor)
+			},
+			expectedErr: domain.ErrAmountOverflow,
+		},
+		{
+			label:    "Div: 12.50 is 12.5% of 100.00",
+			run:      func() (domain.Money, error) { return satang(1250).DivRate(domain.BasisPoints(1250), domain.RoundFloor) },
+			expected: domain.THB(100),
+		},
+		{
+			label: "Div: 10.00 at 3% ceils to 333.34",
+			run: func() (domain.Money, error) {
+				return domain.THB(10).DivRate(domain.PercentRate(3), domain.RoundCeiling)
+			},
+			expected: satang(33334),
+		},
+		{
+			label:       "Fail: div by a zero rate",
+			run:         func() (domain.Money, error) { return domain.THB(10).DivRate(0, domain.RoundFloor) },
+			expectedErr: domain.ErrDivisionByZero,
+		},
+		{
+			label: "Fail: div of max by 0.01% overflows",
+			run: func() (domain.Money, error) {
+				return domain.Money(math.MaxInt64).DivRate(domain.BasisPoints(1), domain.RoundFloor)
+			},
+			expectedErr: domain.ErrAmountOverflow,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			got, err := c.run()
+			if c.expectedErr != nil {
+				assert.ErrorIs(t, err, c.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, got)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
+	_orderHistoryModel "github.com/TewApirat/food-shop/pkg/orderHistory/model"
+	_orderHistoryRepository "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
+)
+
+// GREEN(2) for a gold member: 80 - pair 4 - member 7.60 = 68.40, then 7% of
+// 68.40 = 4.788 off.
+const roundingPromotions = `[
+	{"code":"PAIR","type":"PAIR","title":"Pair 5%","priority":10,"eligibleCodes":["GREEN"],"params":{"discountPercent":5,"bundleSize":2}},
+	{"code":"MEMBER","type":"MEMBER","title":"Member tiers","priority":20},
+	{"code":"SPEND","type":"SPEND_TIER","title":"7% off","priority":30,
+	 "params":{"base":"AFTER_MEMBER","tiers":[{"minSpend":10,"discountPercent":7}]}}
+]`
+
+func TestQuoteOrder_RoundingStrategy(t *testing.T) {
+	type tc struct {
+		label    string
+		rounding *_foodShopModel.RoundingStrategy
+
+		expectedPromotionDiscount domain.Money
+		expectedTotal             domain.Money
+		expectedCashRounding      domain.Money
+		expectedCashTotal         domain.Money
+		expectedRounding          _foodShopModel.RoundingStrategy
+	}
+
+	accounting := _foodShopModel.AccountingRoundingStrategy()
+
+	cases := []tc{
+		{
+			label:                     "Default: 4.788 floors to 4.78, no cash rounding",
+			expectedPromotionDiscount: satang(478),
+			expectedTotal:             satang(6362),
+			expectedCashRounding:      domain.Money(0),
+			expectedCashTotal:         satang(6362),
+			expectedRounding:          _foodShopModel.DefaultRoundingStrategy(),
+		},
+		{
+			label:                     "Accounting: 4.788 rounds half-up to 4.79, cash 63.61 => 63.50",
+			rounding:                  &accounting,
+			expectedPromotionDiscount: satang(479),
+			expectedTotal:             satang(6361),
+			expectedCashRounding:      satang(-11),
+			expectedCashTotal:         satang(6350),
+			expectedRounding:          accounting,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			promotions, err := _foodShopRepository.ParsePromotions([]byte(roundingPromotions))
+			assert.NoError(t, err)
+
+			foodShopRepositoryMock := new(_foodShopRepository.FoodShopRepositoryMock)
+			orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+
+			foodShopRepositoryMock.Test(t)
+			orderHistoryRepositoryMock.Test(t)
+
+			foodShopRepositoryMock.On("FindMenuItemByCode", _foodShopModel.MenuItemCode("GREEN")).
+				Return(_foodShopModel.MenuItem{Code: "GREEN", Name: "Green set", Price: domain.THB(40)}, nil).
+				Once()
+			foodShopRepositoryMock.On("ListPromotions").Return(promotions, nil).Once()
+			orderHistoryRepositoryMock.
+				On("Add", mock.MatchedBy(func(entry _orderHistoryModel.OrderHistoryEntry) bool {
+					return entry.Rounding == c.expectedRounding &&
+						entry.Total == c.expectedTotal &&
+						entry.CashTotal == c.expectedCashTotal
+				})).
+				Return(nil).
+				Once()
+
+			opts := []_foodShopService.Option{_foodShopService.WithMemberRepository(testMemberRepository())}
+			if c.rounding != nil {
+				opts = append(opts, _foodShopService.WithRoundingStrategy(*c.rounding))
+			}
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				foodShopRepositoryMock,
+				orderHistoryRepositoryMock,
+				opts...,
+			)
+
+			res, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{
+				Items:    map[string]int{"GREEN": 2},
+				MemberID: goldMemberID,
+			})
+			assert.NoError(t, err)
+
+			assert.Equal(t, domain.THB(4), res.PairDiscount)
+			assert.Equal(t, satang(760), res.MemberDiscount)
+			assert.Equal(t, c.expectedPromotionDiscount, res.PromotionDiscount)
+			assert.Equal(t, c.expectedTotal, res.Total)
+			assert.Equal(t, c.expectedCashRounding, res.CashRounding)
+			assert.Equal(t, c.expectedCashTotal, res.CashTotal)
+			assert.Equal(t, c.expectedRounding, res.Rounding)
+
+			foodShopRepositoryMock.AssertExpectations(t)
+			orderHistoryRepositoryMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMoney_RoundingModes(t *testing.T) {
+	type tc struct {
+		label    string
+		got      domain.Money
+		expected domain.Money
+	}
+
+	cases := []tc{
+		{label: "Percent floor 4.788", got: satang(6840).PercentRounded(7, domain.RoundFloor), expected: satang(478)},
+		{label: "Percent ceiling 4.788", got: satang(6840).PercentRounded(7, domain.RoundCeiling), expected: satang(479)},
+		{label: "Percent half-up 0.125", got: satang(250).PercentRounded(5, domain.RoundHalfUp), expected: satang(13)},
+		{label: "Percent half-even 0.125", got: satang(250).PercentRounded(5, domain.RoundHalfEven), expected: satang(12)},
+		{label: "Percent half-even 0.135", got: satang(270).PercentRounded(5, domain.RoundHalfEven), expected: satang(14)},
+		{label: "Percent half-up below half", got: satang(249).PercentRounded(5, domain.RoundHalfUp), expected: satang(12)},
+		{label: "Negative floor -2.5", got: satang(-5).Div(2, domain.RoundFloor), expected: satang(-3)},
+		{label: "Negative ceiling -2.5", got: satang(-5).Div(2, domain.RoundCeiling), expected: satang(-2)},
+		{label: "Negative half-up -2.5 away from zero", got: satang(-5).Div(2, domain.RoundHalfUp), expected: satang(-3)},
+		{label: "Negative half-even -2.5", got: satang(-5).Div(2, domain.RoundHalfEven), expected: satang(-2)},
+		{label: "Div exact", got: domain.THB(10).Div(4, domain.RoundHalfUp), expected: satang(250)},
+		{label: "Cash 63.61 => 63.50", got: satang(6361).RoundTo(25, domain.RoundHalfUp), expected: satang(6350)},
+		{label: "Cash 63.63 => 63.75", got: satang(6363).RoundTo(25, domain.RoundHalfUp), expected: satang(6375)},
+		{label: "Cash 63.13 => 63.25", got: satang(6313).RoundTo(25, domain.RoundHalfUp), expected: satang(6325)},
+		{label: "Cash floor 63.74 => 63.50", got: satang(6374).RoundTo(25, domain.RoundFloor), expected: satang(6350)},
+		{label: "No unit leaves the amount", got: satang(6361).RoundTo(0, domain.RoundHalfUp), expected: satang(6361)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			assert.Equal(t, c.expected, c.got)
+		})
+	}
+}
+
+func TestMoney_AllocateRounded(t *testing.T) {
+	cases := []struct {
+		label    string
+		amount   domain.Money
+		weights  []domain.Money
+		mode     domain.RoundingMode
+		expected []domain.Money
+	}{
+		{label: "Half-up: 3.33 each rounds down, the leftover satang goes to the first", amount: 10, weights: []domain.Money{1, 1, 1}, mode: domain.RoundHalfUp, expected: []domain.Money{4, 3, 3}},
+		{label: "Half-up: 0.5 / 0.5 rounded up twice, last gives one back", amount: 1, weights: []domain.Money{1, 1}, mode: domain.RoundHalfUp, expected: []domain.Money{1, 0}},
+		{label: "Ceiling: every part up, the smallest remainders give back", amount: 1000, weights: []domain.Money{30, 40, 50}, mode: domain.RoundCeiling, expected: []domain.Money{250, 333, 417}},
+		{label: "Half-even keeps the total", amount: 7, weights: []domain.Money{2, 2, 2, 2}, mode: domain.RoundHalfEven, expected: []domain.Money{2, 2, 2, 1}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			got := c.amount.AllocateRounded(c.weights, c.mode)
+			assert.Equal(t, c.expected, got)
+
+			var sum domain.Money
+			for _, part := range got {
+				sum += part
+			}
+			assert.Equal(t, c.amount, sum)
+		})
+	}
+}
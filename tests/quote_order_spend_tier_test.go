@@ -0,0 +1,140 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
+	_orderHistoryRepository "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
+)
+
+func spendTierPromotions(base _foodShopModel.SpendBase) string {
+	return fmt.Sprintf(`[
+		{"code":"PAIR","type":"PAIR","title":"Pair 5%%","priority":10,"eligibleCodes":["ORANGE","PINK","GREEN"],"params":{"discountPercent":5,"bundleSize":2}},
+		{"code":"MEMBER","type":"MEMBER","title":"Member tiers","priority":20},
+		{"code":"SPEND","type":"SPEND_TIER","title":"Spend more save more","priority":30,
+		 "params":{"base":%q,"tiers":[{"minSpend":1000,"discountPercent":8},{"minSpend":500,"amountOff":30}]}}
+	]`, base)
+}
+
+func TestQuoteOrder_SpendTierPromotions(t *testing.T) {
+	type tc struct {
+		label string
+		base  _foodShopModel.SpendBase
+		in    _foodShopModel.PurchasingRequest
+
+		expectedPromotionDiscount domain.Money
+		expectedTotal             domain.Money
+		expectedHints             []string
+	}
+
+	cases := []tc{
+		{
+			label:                     "Subtotal: ORANGE(4) 480 => below 500, hint 20 THB",
+			base:                      _foodShopModel.SpendBaseSubtotal,
+			in:                        _foodShopModel.PurchasingRequest{Items: map[string]int{"ORANGE": 4}},
+			expectedPromotionDiscount: domain.THB(0),
+			expectedTotal:             domain.THB(456),
+			expectedHints:             []string{"add 20.00 THB more to unlock 30.00 THB off"},
+		},
+		{
+			label:                     "Subtotal: ORANGE(5) 600 => 30 off, hint to the 8% tier",
+			base:                      _foodShopModel.SpendBaseSubtotal,
+			in:                        _foodShopModel.PurchasingRequest{Items: map[string]int{"ORANGE": 5}},
+			expectedPromotionDiscount: domain.THB(30),
+			expectedTotal:             domain.THB(546),
+			expectedHints:             []string{"add 400.00 THB more to unlock 8% off"},
+		},
+		{
+			label:                     "Subtotal: PURPLE(12) 1080 => 8% of 1080, top tier so no hint",
+			base:                      _foodShopModel.SpendBaseSubtotal,
+			in:                        _foodShopModel.PurchasingRequest{Items: map[string]int{"PURPLE": 12}},
+			expectedPromotionDiscount: satang(8640),
+			expectedTotal:             satang(99360),
+		},
+		{
+			label:                     "After pair: ORANGE(4)+BLUE(1) 510 - pair 24 = 486 => below 500",
+			base:                      _foodShopModel.SpendBaseAfterPair,
+			in:                        _foodShopModel.PurchasingRequest{Items: map[string]int{"ORANGE": 4, "BLUE": 1}},
+			expectedPromotionDiscount: domain.THB(0),
+			expectedTotal:             domain.THB(486),
+			expectedHints:             []string{"add 14.00 THB more to unlock 30.00 THB off"},
+		},
+		{
+			label:                     "After member: RED(10) 500 - member 50 = 450 => below 500",
+			base:                      _foodShopModel.SpendBaseAfterMember,
+			in:                        _foodShopModel.PurchasingRequest{Items: map[string]int{"RED": 10}, MemberID: goldMemberID},
+			expectedPromotionDiscount: domain.THB(0),
+			expectedTotal:             domain.THB(450),
+			expectedHints:             []string{"add 50.00 THB more to unlock 30.00 THB off"},
+		},
+		{
+			label:                     "Subtotal: RED(10) 500 member => 30 off the remaining 450",
+			base:                      _foodShopModel.SpendBaseSubtotal,
+			in:                        _foodShopModel.PurchasingRequest{Items: map[string]int{"RED": 10}, MemberID: goldMemberID},
+			expectedPromotionDiscount: domain.THB(30),
+			expectedTotal:             domain.THB(420),
+			expectedHints:             []string{"add 500.00 THB more to unlock 8% off"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			promotions, err := _foodShopRepository.ParsePromotions([]byte(spendTierPromotions(c.base)))
+			assert.NoError(t, err)
+
+			foodShopRepositoryMock := new(_foodShopRepository.FoodShopRepositoryMock)
+			orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+
+			foodShopRepositoryMock.Test(t)
+			orderHistoryRepositoryMock.Test(t)
+
+			for code, item := range _foodShopRepository.DefaultMenu() {
+				foodShopRepositoryMock.On("FindMenuItemByCode", code).Return(item, nil).Maybe()
+			}
+			foodShopRepositoryMock.On("ListPromotions").Return(promotions, nil).Once()
+			orderHistoryRepositoryMock.On("Add", mock.Anything).Return(nil).Once()
+
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				foodShopRepositoryMock,
+				orderHistoryRepositoryMock,
+				_foodShopService.WithMemberRepository(testMemberRepository()),
+			)
+
+			res, err := foodShopService.QuoteOrder(c.in)
+			assert.NoError(t, err)
+
+			assert.Equal(t, c.expectedPromotionDiscount, res.PromotionDiscount)
+			assert.Equal(t, c.expectedTotal, res.Total)
+
+			var hints []string
+			for _, hint := range res.NextTiers {
+				hints = append(hints, hint.Message())
+			}
+			assert.Equal(t, c.expectedHints, hints)
+
+			orderHistoryRepositoryMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestParsePromotions_InvalidSpendTier(t *testing.T) {
+	cases := []string{
+		`[{"code":"X","type":"SPEND_TIER","params":{}}]`,
+		`[{"code":"X","type":"SPEND_TIER","params":{"base":"AFTER_COUPON","tiers":[{"minSpend":500,"amountOff":30}]}}]`,
+		`[{"code":"X","type":"SPEND_TIER","params":{"tiers":[{"minSpend":0,"amountOff":30}]}}]`,
+		`[{"code":"X","type":"SPEND_TIER","params":{"tiers":[{"minSpend":500,"amountOff":30,"discountPercent":5}]}}]`,
+		`[{"code":"X","type":"SPEND_TIER","params":{"tiers":[{"minSpend":500,"amountOff":30},{"minSpend":500,"discountPercent":5}]}}]`,
+	}
+
+	for _, data := range cases {
+		_, err := _foodShopRepository.ParsePromotions([]byte(data))
+		assert.Error(t, err, data)
+	}
+}
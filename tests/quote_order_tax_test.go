@@ -0,0 +1,193 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
+	_orderHistoryModel "github.com/TewApirat/food-shop/pkg/orderHistory/model"
+	_orderHistoryRepository "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
+)
+
+func TestQuoteOrder_Taxes(t *testing.T) {
+	type tc struct {
+		label string
+		tax   _foodShopModel.TaxPolicy
+
+		expectedPreTax        domain.Money
+		expectedServiceCharge domain.Money
+		expectedVAT           domain.Money
+		expectedGrandTotal    domain.Money
+	}
+
+	beforeDiscounts := _foodShopModel.ThaiTaxPolicy(_foodShopModel.MenuPricingVATExclusive)
+	beforeDiscounts.Base = _foodShopModel.TaxBaseBeforeDiscounts
+	inclusiveBeforeDiscounts := _foodShopModel.ThaiTaxPolicy(_foodShopModel.MenuPricingVATInclusive)
+	inclusiveBeforeDiscounts.Base = _foodShopModel.TaxBaseBeforeDiscounts
+
+	// RED(2)+GREEN(2) = 180, pair 4 off => 176 before taxes.
+	cases := []tc{
+		{
+			label:              "No tax policy: grand total is the total",
+			expectedPreTax:     domain.THB(176),
+			expectedGrandTotal: domain.THB(176),
+		},
+		{
+			label:                 "VAT-exclusive: 10% of 176 = 17.60, 7% of 193.60 = 13.552 => 13.55",
+			tax:                   _foodShopModel.ThaiTaxPolicy(_foodShopModel.MenuPricingVATExclusive),
+			expectedPreTax:        domain.THB(176),
+			expectedServiceCharge: satang(1760),
+			expectedVAT:           satang(1355),
+			expectedGrandTotal:    satang(20715),
+		},
+		{
+			label:                 "VAT-inclusive: 176 holds 164.49 + 11.51 VAT, 10% of 164.49 = 16.45 plus 1.15 VAT on it",
+			tax:                   _foodShopModel.ThaiTaxPolicy(_foodShopModel.MenuPricingVATInclusive),
+			expectedPreTax:        satang(16449),
+			expectedServiceCharge: satang(1645),
+			expectedVAT:           satang(1266),
+			expectedGrandTotal:    satang(19360),
+		},
+		{
+			label:                 "Before discounts: charges on 180, pair 4 still comes off",
+			tax:                   beforeDiscounts,
+			expectedPreTax:        domain.THB(176),
+			expectedServiceCharge: domain.THB(18),
+			expectedVAT:           satang(1386),
+			expectedGrandTotal:    satang(20786),
+		},
+		{
+			label:                 "VAT-inclusive before discounts: 10% of 168.22 (180 without VAT), VAT is what 176 holds plus 1.18 on the charge",
+			tax:                   inclusiveBeforeDiscounts,
+			expectedPreTax:        satang(16449),
+			expectedServiceCharge: satang(1682),
+			expectedVAT:           satang(1269),
+			expectedGrandTotal:    satang(19400),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			foodShopRepositoryMock := new(_foodShopRepository.FoodShopRepositoryMock)
+			orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+
+			foodShopRepositoryMock.Test(t)
+			orderHistoryRepositoryMock.Test(t)
+
+			for code, item := range _foodShopRepository.DefaultMenu() {
+				foodShopRepositoryMock.On("FindMenuItemByCode", code).Return(item, nil).Maybe()
+			}
+			expectDefaultPromotions(foodShopRepositoryMock)
+			orderHistoryRepositoryMock.
+				On("Add", mock.MatchedBy(func(entry _orderHistoryModel.OrderHistoryEntry) bool {
+					return entry.Tax == c.tax &&
+						entry.PreTax == c.expectedPreTax &&
+						entry.ServiceCharge == c.expectedServiceCharge &&
+						entry.VAT == c.expectedVAT &&
+						entry.GrandTotal == c.expectedGrandTotal
+				})).
+				Return(nil).
+				Once()
+
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				foodShopRepositoryMock,
+				orderHistoryRepositoryMock,
+				_foodShopService.WithTaxPolicy(c.tax),
+			)
+
+			res, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{
+				Items: map[string]int{"RED": 2, "GREEN": 2},
+			})
+			assert.NoError(t, err)
+
+			assert.Equal(t, domain.THB(176), res.Total)
+			assert.Equal(t, c.expectedPreTax, res.PreTax)
+			assert.Equal(t, c.expectedServiceCharge, res.ServiceCharge)
+			assert.Equal(t, c.expectedVAT, res.VAT)
+			assert.Equal(t, c.expectedGrandTotal, res.GrandTotal)
+			assert.Equal(t, res.GrandTotal, res.PreTax.Add(res.ServiceCharge).Add(res.VAT))
+			assert.Equal(t, res.GrandTotal, res.CashTotal)
+
+			foodShopRepositoryMock.AssertExpectations(t)
+			orderHistoryRepositoryMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestQuoteOrder_TaxRounding(t *testing.T) {
+	type tc struct {
+		label    string
+		rounding _foodShopModel.RoundingStrategy
+
+		expectedVAT       domain.Money
+		expectedCashTotal domain.Money
+	}
+
+	// 7% of 1.50 is 0.105 THB.
+	cases := []tc{
+		{
+			label:             "Default: banker's rounding, 0.105 => 0.10",
+			rounding:          _foodShopModel.DefaultRoundingStrategy(),
+			expectedVAT:       satang(10),
+			expectedCashTotal: satang(160),
+		},
+		{
+			label:             "Accounting: banker's rounding too, 0.105 => 0.10, cash 1.60 => 1.50",
+			rounding:          _foodShopModel.AccountingRoundingStrategy(),
+			expectedVAT:       satang(10),
+			expectedCashTotal: satang(150),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			foodShopRepositoryMock := new(_foodShopRepository.FoodShopRepositoryMock)
+			orderHistoryRepositoryMock := new(_orderHistoryRepository.OrderHistoryRepositoryMock)
+
+			foodShopRepositoryMock.Test(t)
+			orderHistoryRepositoryMock.Test(t)
+
+			foodShopRepositoryMock.On("FindMenuItemByCode", _foodShopModel.MenuItemCode("WATER")).
+				Return(_foodShopModel.MenuItem{Code: "WATER", Name: "Water", Price: satang(150)}, nil).
+				Once()
+			orderHistoryRepositoryMock.On("Add", mock.Anything).Return(nil).Once()
+
+			foodShopService := _foodShopService.NewFoodShopServiceImpl(
+				foodShopRepositoryMock,
+				orderHistoryRepositoryMock,
+				_foodShopService.WithPromotionRules(),
+				_foodShopService.WithRoundingStrategy(c.rounding),
+				_foodShopService.WithTaxPolicy(_foodShopModel.TaxPolicy{VATRate: domain.PercentRate(7)}),
+			)
+
+			res, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{
+				Items: map[string]int{"WATER": 1},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, c.expectedVAT, res.VAT)
+			assert.Equal(t, satang(150).Add(c.expectedVAT), res.GrandTotal)
+			assert.Equal(t, c.expectedCashTotal, res.CashTotal)
+
+			foodShopRepositoryMock.AssertExpectations(t)
+			orderHistoryRepositoryMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaxPolicyByName(t *testing.T) {
+	none, err := _foodShopModel.TaxPolicyByName("none")
+	assert.NoError(t, err)
+	assert.False(t, none.Charges())
+
+	inclusive, err := _foodShopModel.TaxPolicyByName("thai-inclusive")
+	assert.NoError(t, err)
+	assert.Equal(t, _foodShopModel.ThaiTaxPolicy(_foodShopModel.MenuPricingVATInclusive), inclusive)
+
+	_, err = _foodShopModel.TaxPolicyByName("flat")
+	assert.Error(t, err)
+}
@@ -32,7 +32,6 @@ func TestQuoteOrderSuccess(t *testing.T) {
 			label: "Success: mixed items, pair applies to GREEN(2), no member",
 			in: _foodShopModel.PurchasingRequest{
 				Items:  map[string]int{"RED": 1, "GREEN": 2},
-				Member: false,
 			},
 			expected: _foodShopModel.OrderQuote{
 				Subtotal:       domain.THB(130),
@@ -56,8 +55,8 @@ func TestQuoteOrderSuccess(t *testing.T) {
 		{
 			label: "Success: member stacks after pair discount (GREEN 2)",
 			in: _foodShopModel.PurchasingRequest{
-				Items:  map[string]int{"GREEN": 2},
-				Member: true,
+				Items:    map[string]int{"GREEN": 2},
+				MemberID: goldMemberID,
 			},
 			expected: _foodShopModel.OrderQuote{
 				Subtotal:       domain.THB(80),
@@ -85,6 +84,7 @@ func TestQuoteOrderSuccess(t *testing.T) {
 			orderHistoryRepositoryMock.Test(t)
 
 			c.setupMenuMock(foodShopRepositoryMock)
+			expectDefaultPromotions(foodShopRepositoryMock)
 
 			orderHistoryRepositoryMock.
 				On("Add", mock.MatchedBy(func(entry _orderHistoryModel.OrderHistoryEntry) bool {
@@ -99,7 +99,7 @@ func TestQuoteOrderSuccess(t *testing.T) {
 						return false
 					}
 
-					if entry.Member != c.in.Member {
+					if entry.MemberID != c.in.MemberID {
 						return false
 					}
 					if entry.Subtotal != c.expected.Subtotal {
@@ -132,6 +132,7 @@ func TestQuoteOrderSuccess(t *testing.T) {
 			foodShopService := _foodShopService.NewFoodShopServiceImpl(
 				foodShopRepositoryMock,
 				orderHistoryRepositoryMock,
+				_foodShopService.WithMemberRepository(testMemberRepository()),
 			)
 
 			result, err := foodShopService.QuoteOrder(c.in)
@@ -160,7 +161,6 @@ func TestQuoteOrderFail(t *testing.T) {
 			label: "Fail: empty order",
 			in: _foodShopModel.PurchasingRequest{
 				Items:  map[string]int{},
-				Member: false,
 			},
 			check: func(t *testing.T, err error) {
 				var target *_foodShopException.EmptyOrderError
@@ -171,7 +171,6 @@ func TestQuoteOrderFail(t *testing.T) {
 			label: "Fail: invalid quantity",
 			in: _foodShopModel.PurchasingRequest{
 				Items:  map[string]int{"RED": 0},
-				Member: false,
 			},
 			check: func(t *testing.T, err error) {
 				var target *_foodShopException.InvalidQuantityError
@@ -183,7 +182,6 @@ func TestQuoteOrderFail(t *testing.T) {
 			label: "Fail: invalid item code (blank)",
 			in: _foodShopModel.PurchasingRequest{
 				Items:  map[string]int{"   ": 1},
-				Member: false,
 			},
 			check: func(t *testing.T, err error) {
 				var target *_foodShopException.InvalidItemCodeError
@@ -225,7 +223,6 @@ func TestQuoteOrderFail_UnknownMenuItem(t *testing.T) {
 
 	in := _foodShopModel.PurchasingRequest{
 		Items:  map[string]int{"BLACK": 1},
-		Member: false,
 	}
 
 	foodShopRepositoryMock.
@@ -265,7 +262,6 @@ func TestQuoteOrder_Normalization(t *testing.T) {
 			label: "Normalize code: \" green \" => GREEN",
 			in: _foodShopModel.PurchasingRequest{
 				Items:  map[string]int{" green ": 2},
-				Member: false,
 			},
 			setupMenuMock: func(r *_foodShopRepository.FoodShopRepositoryMock) {
 				r.On("FindMenuItemByCode", _foodShopModel.MenuItemCode("GREEN")).
@@ -284,7 +280,6 @@ func TestQuoteOrder_Normalization(t *testing.T) {
 			label: "Normalize + merge qty: {\" green \":1,\"GREEN\":1} => GREEN=2",
 			in: _foodShopModel.PurchasingRequest{
 				Items:  map[string]int{" green ": 1, "GREEN": 1},
-				Member: false,
 			},
 			setupMenuMock: func(r *_foodShopRepository.FoodShopRepositoryMock) {
 				r.On("FindMenuItemByCode", _foodShopModel.MenuItemCode("GREEN")).
@@ -311,6 +306,7 @@ func TestQuoteOrder_Normalization(t *testing.T) {
 			orderHistoryRepositoryMock.Test(t)
 
 			c.setupMenuMock(foodShopRepositoryMock)
+			expectDefaultPromotions(foodShopRepositoryMock)
 
 			orderHistoryRepositoryMock.
 				On("Add", mock.Anything).
@@ -318,7 +314,7 @@ func TestQuoteOrder_Normalization(t *testing.T) {
 					entry := args.Get(0).(_orderHistoryModel.OrderHistoryEntry)
 
 					// ตรวจยอดรวม
-					assert.Equal(t, c.in.Member, entry.Member)
+					assert.Equal(t, c.in.MemberID, entry.MemberID)
 					assert.Equal(t, c.expected.Subtotal, entry.Subtotal)
 					assert.Equal(t, c.expected.PairDiscount, entry.PairDiscount)
 					assert.Equal(t, c.expected.MemberDiscount, entry.MemberDiscount)
@@ -0,0 +1,237 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/TewApirat/food-shop/pkg/foodShop/domain"
+	_foodShopException "github.com/TewApirat/food-shop/pkg/foodShop/exception"
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_foodShopService "github.com/TewApirat/food-shop/pkg/foodShop/service"
+	_orderHistoryRepository "github.com/TewApirat/food-shop/pkg/orderHistory/repository"
+	_taxInvoiceModel "github.com/TewApirat/food-shop/pkg/taxInvoice/model"
+	_taxInvoiceRepository "github.com/TewApirat/food-shop/pkg/taxInvoice/repository"
+)
+
+var taxInvoiceTestNow = time.Date(2026, time.October, 17, 12, 30, 0, 0, time.FixedZone("ICT", 7*60*60))
+
+func testBuyer() _taxInvoiceModel.Party {
+	return _taxInvoiceModel.Party{
+		Name:    "Siam Trading Co., Ltd.",
+		TaxID:   "3105500123452",
+		Address: "1 Rama IV Road, Bangkok 10500",
+	}
+}
+
+// newTaxInvoiceTestService quotes RED(1)+GREEN(2) as order #1 and RED(2)
+// as order #2 under the given tax policy.
+func newTaxInvoiceTestService(t *testing.T, tax _foodShopModel.TaxPolicy, opts ..._foodShopService.Option) _foodShopService.FoodShopService {
+	t.Helper()
+
+	opts = append([]_foodShopService.Option{
+		_foodShopService.WithClock(domain.FixedClock(taxInvoiceTestNow)),
+		_foodShopService.WithTaxPolicy(tax),
+	}, opts...)
+	foodShopService := _foodShopService.NewFoodShopServiceImpl(
+		_foodShopRepository.NewFoodShopRepositoryImpl(_foodShopRepository.DefaultMenu(), _foodShopRepository.DefaultPromotions()),
+		_orderHistoryRepository.NewOrderHistoryRepositoryImpl(),
+		opts...,
+	)
+
+	for _, items := range []map[string]int{{"RED": 1, "GREEN": 2}, {"RED": 2}} {
+		_, err := foodShopService.QuoteOrder(_foodShopModel.PurchasingRequest{Items: items})
+		assert.NoError(t, err)
+	}
+	return foodShopService
+}
+
+func TestIssueTaxInvoice(t *testing.T) {
+	foodShopService := newTaxInvoiceTestService(t,
+		_foodShopModel.ThaiTaxPolicy(_foodShopModel.MenuPricingVATExclusive),
+		_foodShopService.WithTaxInvoiceRepository(_taxInvoiceRepository.NewTaxInvoiceRepositoryImpl("TI")),
+	)
+
+	buyer := testBuyer()
+	buyer.TaxID = "3-1055-00123-45-2"
+
+	// RED(1)+GREEN(2) = 130, pair 4 off => 126, +12.60 service charge,
+	// +9.70 VAT on 138.60 => 148.30.
+	first, err := foodShopService.IssueTaxInvoice(_taxInvoiceModel.IssueRequest{OrderNo: 1, Buyer: buyer})
+	assert.NoError(t, err)
+	assert.Equal(t, "TI000001", first.Number)
+	assert.Equal(t, 1, first.Sequence)
+	assert.Equal(t, taxInvoiceTestNow, first.IssuedAt)
+	assert.Equal(t, _taxInvoiceModel.DefaultSeller(), first.Seller)
+	assert.Equal(t, "3105500123452", first.Buyer.TaxID)
+	assert.Equal(t, _taxInvoiceModel.HeadOfficeBranch, first.Buyer.Branch)
+	assert.Len(t, first.Lines, 2)
+	assert.Equal(t, domain.THB(130), first.Subtotal)
+	assert.Equal(t, domain.THB(4), first.Discount)
+	assert.Equal(t, domain.THB(126), first.Total)
+	assert.Equal(t, satang(1260), first.ServiceCharge)
+	assert.Equal(t, satang(13860), first.TaxableAmount)
+	assert.Equal(t, satang(970), first.VAT)
+	assert.Equal(t, satang(14830), first.GrandTotal)
+
+	_, err = foodShopService.IssueTaxInvoice(_taxInvoiceModel.IssueRequest{OrderNo: 1, Buyer: testBuyer()})
+	var issued *_foodShopException.TaxInvoiceAlreadyIssuedError
+	if assert.ErrorAs(t, err, &issued) {
+		assert.Equal(t, _foodShopException.TaxInvoiceAlreadyIssuedError{OrderNo: 1, Number: "TI000001"}, *issued)
+	}
+
+	_, err = foodShopService.IssueTaxInvoice(_taxInvoiceModel.IssueRequest{OrderNo: 9, Buyer: testBuyer()})
+	var notFound *_foodShopException.OrderNotFoundError
+	assert.ErrorAs(t, err, &notFound)
+
+	// Rejected requests above took no number, so order #2 gets the next one.
+	second, err := foodShopService.IssueTaxInvoice(_taxInvoiceModel.IssueRequest{OrderNo: 2, Buyer: testBuyer()})
+	assert.NoError(t, err)
+	assert.Equal(t, "TI000002", second.Number)
+	assert.Equal(t, satang(11770), second.GrandTotal)
+
+	reprint, err := foodShopService.GetTaxInvoice(" ti000002 ")
+	assert.NoError(t, err)
+	assert.Equal(t, second, reprint)
+
+	_, err = foodShopService.GetTaxInvoice("TI000003")
+	var missing *_foodShopException.TaxInvoiceNotFoundError
+	assert.ErrorAs(t, err, &missing)
+
+	invoices, err := foodShopService.ListTaxInvoices()
+	assert.NoError(t, err)
+	assert.Equal(t, []_taxInvoiceModel.TaxInvoice{first, second}, invoices)
+}
+
+func TestIssueTaxInvoice_InvalidBuyer(t *testing.T) {
+	type tc struct {
+		label         string
+		edit          func(p *_taxInvoiceModel.Party)
+		expectedField string
+	}
+
+	cases := []tc{
+		{label: "Fail: no name", edit: func(p *_taxInvoiceModel.Party) { p.Name = "  " }, expectedField: "name"},
+		{label: "Fail: no address", edit: func(p *_taxInvoiceModel.Party) { p.Address = "" }, expectedField: "address"},
+		{label: "Fail: tax ID too short", edit: func(p *_taxInvoiceModel.Party) { p.TaxID = "310550012345" }, expectedField: "tax ID"},
+		{label: "Fail: tax ID check digit", edit: func(p *_taxInvoiceModel.Party) { p.TaxID = "3105500123451" }, expectedField: "tax ID"},
+		{label: "Fail: branch not 5 digits", edit: func(p *_taxInvoiceModel.Party) { p.Branch = "1" }, expectedField: "branch"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			taxInvoiceRepositoryMock := new(_taxInvoiceRepository.TaxInvoiceRepositoryMock)
+			taxInvoiceRepositoryMock.Test(t)
+
+			foodShopService := newTaxInvoiceTestService(t,
+				_foodShopModel.ThaiTaxPolicy(_foodShopModel.MenuPricingVATExclusive),
+				_foodShopService.WithTaxInvoiceRepository(taxInvoiceRepositoryMock),
+			)
+
+			buyer := testBuyer()
+			c.edit(&buyer)
+			_, err := foodShopService.IssueTaxInvoice(_taxInvoiceModel.IssueRequest{OrderNo: 1, Buyer: buyer})
+
+			var target *_foodShopException.InvalidBuyerError
+			if assert.ErrorAs(t, err, &target) {
+				assert.Equal(t, c.expectedField, target.Field)
+			}
+			taxInvoiceRepositoryMock.AssertNotCalled(t, "Issue")
+		})
+	}
+}
+
+func TestIssueTaxInvoice_NotAvailable(t *testing.T) {
+	cases := []struct {
+		label string
+		tax   _foodShopModel.TaxPolicy
+		opts  []_foodShopService.Option
+	}{
+		{
+			label: "Fail: order priced without VAT",
+			opts:  []_foodShopService.Option{_foodShopService.WithTaxInvoiceRepository(_taxInvoiceRepository.NewTaxInvoiceRepositoryImpl("TI"))},
+		},
+		{
+			label: "Fail: tax invoices not enabled",
+			tax:   _foodShopModel.ThaiTaxPolicy(_foodShopModel.MenuPricingVATInclusive),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.label, func(t *testing.T) {
+			foodShopService := newTaxInvoiceTestService(t, c.tax, c.opts...)
+
+			_, err := foodShopService.IssueTaxInvoice(_taxInvoiceModel.IssueRequest{OrderNo: 1, Buyer: testBuyer()})
+
+			var target *_foodShopException.TaxInvoiceNotAvailableError
+			if assert.ErrorAs(t, err, &target) {
+				assert.Equal(t, 1, target.OrderNo)
+			}
+		})
+	}
+}
+
+func TestTaxInvoice_Render(t *testing.T) {
+	foodShopService := newTaxInvoiceTestService(t,
+		_foodShopModel.ThaiTaxPolicy(_foodShopModel.MenuPricingVATInclusive),
+		_foodShopService.WithTaxInvoiceRepository(_taxInvoiceRepository.NewTaxInvoiceRepositoryImpl("TI")),
+	)
+
+	buyer := testBuyer()
+	buyer.Branch = "00002"
+	invoice, err := foodShopService.IssueTaxInvoice(_taxInvoiceModel.IssueRequest{OrderNo: 2, Buyer: buyer})
+	assert.NoError(t, err)
+
+	text := invoice.Text()
+	for _, want := range []string{
+		"TAX INVOICE",
+		"No.              : TI000001",
+		"Date             : 2026-10-17 12:30",
+		"Seller           : Food Shop Co., Ltd. (Head office)",
+		"Buyer            : Siam Trading Co., Ltd. (Branch 00002)",
+		"Tax ID           : 3105500123452",
+		"RED     | Red set      |   2 | 50.00 THB  | 100.00 THB",
+		"VAT              : " + invoice.VAT.String() + " (7%)",
+		"In words         : " + invoice.GrandTotal.ThaiWords(),
+		"Menu prices include VAT.",
+	} {
+		assert.Contains(t, text, want)
+	}
+	assert.Equal(t, invoice.GrandTotal, invoice.TaxableAmount.Add(invoice.VAT))
+
+	data, err := invoice.JSON()
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"number": "TI000001"`)
+	assert.Contains(t, string(data), `"grandTotal": "`+invoice.GrandTotal.Decimal()+`"`)
+
+	var decoded _taxInvoiceModel.TaxInvoice
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.True(t, invoice.IssuedAt.Equal(decoded.IssuedAt))
+	assert.True(t, invoice.OrderedAt.Equal(decoded.OrderedAt))
+	decoded.IssuedAt, decoded.OrderedAt = invoice.IssuedAt, invoice.OrderedAt
+	assert.Equal(t, invoice, decoded)
+}
+
+func TestValidTaxID(t *testing.T) {
+	cases := []struct {
+		id       string
+		expected bool
+	}{
+		{id: "0105556123453", expected: true},
+		{id: "3105500123452", expected: true},
+		{id: "3105500123451", expected: false},
+		{id: "310550012345", expected: false},
+		{id: "31055001234520", expected: false},
+		{id: "310550012345x", expected: false},
+		{id: "", expected: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.id, func(t *testing.T) {
+			assert.Equal(t, c.expected, _taxInvoiceModel.ValidTaxID(c.id))
+		})
+	}
+}
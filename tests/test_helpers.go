@@ -1,6 +1,13 @@
 package tests
 
-import _foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+import (
+	"time"
+
+	_foodShopModel "github.com/TewApirat/food-shop/pkg/foodShop/model"
+	_foodShopRepository "github.com/TewApirat/food-shop/pkg/foodShop/repository"
+	_memberModel "github.com/TewApirat/food-shop/pkg/member/model"
+	_memberRepository "github.com/TewApirat/food-shop/pkg/member/repository"
+)
 
 func lineQtyMap(lines []_foodShopModel.OrderLine) map[_foodShopModel.MenuItemCode]int {
 	m := make(map[_foodShopModel.MenuItemCode]int, len(lines))
@@ -9,3 +16,24 @@ func lineQtyMap(lines []_foodShopModel.OrderLine) map[_foodShopModel.MenuItemCod
 	}
 	return m
 }
+
+func expectDefaultPromotions(r *_foodShopRepository.FoodShopRepositoryMock) {
+	r.On("ListPromotions").Return(_foodShopRepository.DefaultPromotions(), nil).Once()
+}
+
+const goldMemberID = "G001"
+
+func testMemberRepository() _memberRepository.MemberRepository {
+	return _memberRepository.NewMemberRepositoryImpl([]_memberModel.Member{
+		{ID: "S001", CardNumber: "5001", Name: "Silver", Tier: _memberModel.MemberTierSilver},
+		{ID: goldMemberID, CardNumber: "5002", Name: "Gold", Tier: _memberModel.MemberTierGold},
+		{ID: "P001", CardNumber: "5003", Name: "Platinum", Tier: _memberModel.MemberTierPlatinum},
+		{
+			ID:        "E001",
+			Name:      "Expired",
+			Tier:      _memberModel.MemberTierGold,
+			ExpiresAt: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{ID: "X001", Name: "Suspended", Tier: _memberModel.MemberTierPlatinum, Suspended: true},
+	})
+}